@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"time"
+
+	"github.com/snrsw/gh-own/internal/cache"
+	"github.com/snrsw/gh-own/internal/config"
+	"github.com/snrsw/gh-own/internal/ui"
+)
+
+// snapshot is the on-disk form of the last fetched search results of a command.
+type snapshot[R any] struct {
+	Result     R                 `json:"result"`
+	Login      string            `json:"login"`
+	HostLogins map[string]string `json:"host_logins"`
+}
+
+// resultStore returns the cache store for command's results. The cache is
+// keyed by everything that shapes the results, so changing hosts, queries or
+// the limit never shows results fetched for another setup.
+func resultStore(command string, cfg config.Config, cc config.CommandConfig) (*cache.Store, error) {
+	key, err := json.Marshal(struct {
+		Hosts   []string          `json:"hosts"`
+		Queries map[string]string `json:"queries"`
+		Limit   int               `json:"limit"`
+	}{resolveHosts(cfg), cc.Queries, searchLimit(cc)})
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(key)
+	return cache.NewResultStore(command + "-" + hex.EncodeToString(sum[:8]))
+}
+
+// failedTabsKeeper is implemented by results that can take the tabs whose
+// search failed from a previous result.
+type failedTabsKeeper[R any] interface {
+	KeepFailedTabs(prev R) R
+}
+
+// saveSnapshot caches snap for command. Tabs whose search failed keep their
+// previously cached results, so a transient failure does not empty them on
+// the next launch. Failures are only logged, since the cache is an optimization.
+func saveSnapshot[R failedTabsKeeper[R]](command string, cfg config.Config, cc config.CommandConfig, snap snapshot[R]) {
+	store, err := resultStore(command, cfg, cc)
+	if err == nil {
+		var prev snapshot[R]
+		if _, readErr := store.Read(&prev); readErr == nil {
+			snap.Result = snap.Result.KeepFailedTabs(prev.Result)
+		}
+		err = store.Write(snap)
+	}
+	if err != nil {
+		slog.Debug("failed to write result cache", "command", command, "error", err)
+	}
+}
+
+// loadSnapshot returns the cached results of command and when they were fetched.
+func loadSnapshot[R any](command string, cfg config.Config, cc config.CommandConfig) (snapshot[R], time.Time, error) {
+	var snap snapshot[R]
+	store, err := resultStore(command, cfg, cc)
+	if err != nil {
+		return snap, time.Time{}, err
+	}
+	cachedAt, err := store.Read(&snap)
+	return snap, cachedAt, err
+}
+
+// noCacheError explains why --offline has nothing to show.
+func noCacheError(command string, err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("no cached results for `gh own %s` with the current hosts and queries; run it once without --offline first", command)
+	}
+	return fmt.Errorf("failed to read cached results: %w", err)
+}
+
+// offlineInfo describes the age of the results shown with --offline.
+func offlineInfo(cachedAt time.Time) string {
+	return "offline, cached " + ui.UpdatedAgo(cachedAt.Format(time.RFC3339))
+}
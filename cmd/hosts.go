@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/auth"
+	"github.com/snrsw/gh-own/internal/cache"
+	"github.com/snrsw/gh-own/internal/config"
+	"github.com/snrsw/gh-own/internal/gh"
+)
+
+var hostnames []string
+
+// resolveHosts returns the hosts to query, preferring --hostname over the
+// config file and falling back to the gh default host.
+func resolveHosts(cfg config.Config) []string {
+	hosts := hostnames
+	if len(hosts) == 0 {
+		hosts = cfg.Hosts
+	}
+
+	seen := make(map[string]bool, len(hosts))
+	unique := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if h == "" || seen[h] {
+			continue
+		}
+		seen[h] = true
+		unique = append(unique, h)
+	}
+	if len(unique) == 0 {
+		host, _ := auth.DefaultHost()
+		return []string{host}
+	}
+	return unique
+}
+
+// graphQLClient returns a GraphQL client authenticated for host.
+func graphQLClient(host string) (*api.GraphQLClient, error) {
+	return api.NewGraphQLClient(api.ClientOptions{Host: host})
+}
+
+// teamSlugs returns the slugs of the teams of the current user on host,
+// cached like for the team searches.
+func teamSlugs(host string) ([]string, error) {
+	restClient, err := api.NewRESTClient(api.ClientOptions{Host: host})
+	if err != nil {
+		return nil, err
+	}
+	store, err := cache.NewStoreForHost(host)
+	if err != nil {
+		return nil, err
+	}
+	return gh.GetTeamSlugsWithCache(restClient, store, 6*time.Hour)
+}
+
+// fetchHosts calls fetch for every host concurrently and returns the results
+// of the hosts that succeeded in host order, along with the errors of the
+// others. An error is returned only when every host fails.
+func fetchHosts[T any](hosts []string, fetch func(host string) (T, error)) ([]T, []error, error) {
+	chans := make([]chan result[T], len(hosts))
+	for i, host := range hosts {
+		chans[i] = make(chan result[T], 1)
+		go func() {
+			v, err := fetch(host)
+			chans[i] <- result[T]{v: v, err: err}
+		}()
+	}
+
+	results := make([]T, 0, len(hosts))
+	var failed []error
+	for i, ch := range chans {
+		r := <-ch
+		if r.err != nil {
+			if len(hosts) > 1 {
+				r.err = fmt.Errorf("%s: %w", hosts[i], r.err)
+			}
+			failed = append(failed, r.err)
+			continue
+		}
+		results = append(results, r.v)
+	}
+	if len(results) == 0 {
+		return nil, nil, failed[0]
+	}
+	return results, failed, nil
+}
+
+// withHostErrors adds the errors of the hosts that failed to the tab of every
+// search in queries, so the tabs show the results of the other hosts along
+// with the error. The participated* searches share the "participated" tab.
+func withHostErrors(errs map[string]error, failed []error, queries map[string]string) map[string]error {
+	if len(failed) == 0 {
+		return errs
+	}
+	if errs == nil {
+		errs = make(map[string]error, len(queries))
+	}
+	hostErr := errors.Join(failed...)
+	for key := range queries {
+		if strings.HasPrefix(key, "participated") {
+			key = "participated"
+		}
+		errs[key] = errors.Join(errs[key], hostErr)
+	}
+	return errs
+}
@@ -2,9 +2,10 @@
 package cmd
 
 import (
+	"errors"
+	"log/slog"
 	"time"
 
-	tea "github.com/charmbracelet/bubbletea"
 	"github.com/cli/go-gh/v2/pkg/api"
 	"github.com/snrsw/gh-own/internal/cache"
 	"github.com/snrsw/gh-own/internal/config"
@@ -20,6 +21,7 @@ var issueCmd = &cobra.Command{
 	Use:   "issue",
 	Short: "GitHub CLI extension to list your owned issues.",
 	Long:  "GitHub CLI extension to list your owned issues.",
+	Args:  cobra.NoArgs,
 	RunE: func(_ *cobra.Command, _ []string) error {
 		defer timing.Track("issue:total")()
 
@@ -30,103 +32,204 @@ var issueCmd = &cobra.Command{
 			return cfgErr
 		}
 
-		fetch := ui.FetchCmd(func() ([]ui.Tab, error) {
-			if demo {
-				ig := issue.NewGroupedIssues(demodata.IssueSearchResult(), "")
-				return ig.BuildTabs(), nil
+		if exportRequested() {
+			if err := validateExportFields(issue.ExportFields); err != nil {
+				return err
 			}
-
-			done := timing.Track("issue:login")
-			username, err := gh.CurrentLogin()
-			done()
+			ig, err := loadIssues(cfg)
 			if err != nil {
-				return nil, err
+				return err
 			}
+			warn(ig.Warnings())
+			return writeExport(ig.Export())
+		}
 
-			entries := config.ResolveQueries(config.MergeIssueQueries(cfg.Issue.Queries), username)
-
-			done = timing.Track("issue:rest-client")
-			restClient, err := api.DefaultRESTClient()
-			done()
+		if !interactive() {
+			ig, err := loadIssues(cfg)
 			if err != nil {
-				return nil, err
+				return err
 			}
+			warn(ig.Warnings())
+			return writeTable(ig.Table())
+		}
 
-			done = timing.Track("issue:graphql-client")
-			client, err := api.DefaultGraphQLClient()
-			done()
+		if offline {
+			ig, cachedAt, err := cachedIssues(cfg)
 			if err != nil {
-				return nil, err
+				return noCacheError("issue", err)
 			}
+			return runUI(ui.NewModel(ig.BuildTabs()).WithInfo(offlineInfo(cachedAt)))
+		}
 
-			done = timing.Track("issue:cache-store")
-			store, err := cache.NewStore()
-			done()
+		fetch := ui.FetchWithInfoCmd(func() ([]ui.Tab, string, error) {
+			ig, err := fetchIssues(cfg)
 			if err != nil {
-				return nil, err
+				return nil, "", err
 			}
+			return ig.BuildTabs(), ig.RateLimit.String(), nil
+		})
 
-			userCh := make(chan result[*gh.IssueSearchResult], 1)
-			go func() {
-				defer timing.Track("issue:search-user")()
-				issues, err := gh.SearchIssues(client, entries)
-				userCh <- result[*gh.IssueSearchResult]{v: issues, err: err}
-			}()
-
-			teamCh := make(chan result[*gh.IssueSearchResult], 1)
-			go func() {
-				defer timing.Track("issue:search-teams-total")()
-
-				teamDone := timing.Track("issue:get-team-slugs")
-				teams, err := gh.GetTeamSlugsWithCache(restClient, store, 6*time.Hour)
-				teamDone()
-				if err != nil {
-					teamCh <- result[*gh.IssueSearchResult]{v: nil, err: err}
-					return
-				}
-
-				teamDone = timing.Track("issue:search-teams")
-				issues, err := gh.SearchIssuesTeams(client, username, teams)
-				teamDone()
-				if err != nil {
-					teamCh <- result[*gh.IssueSearchResult]{v: nil, err: err}
-					return
-				}
-				teamCh <- result[*gh.IssueSearchResult]{v: issues, err: err}
-			}()
-
-			userResult := <-userCh
-			if userResult.err != nil {
-				return nil, userResult.err
-			}
+		m := ui.NewLoadingModel(fetch)
+		if ig, cachedAt, err := cachedIssues(cfg); err == nil {
+			m = ui.NewStaleModel(ig.BuildTabs(), cachedAt, fetch)
+		}
+		if !demo {
+			m = m.WithActions(issue.Actions(graphQLClient)...).
+				WithDetail(issue.Detail(graphQLClient)).
+				WithEditor(gh.Editor())
+		}
+		return runUI(m.WithAutoRefresh(refreshInterval(cfg)))
+	},
+}
 
-			teamResult := <-teamCh
-			if teamResult.err != nil {
-				return nil, teamResult.err
-			}
+func fetchIssues(cfg config.Config) (*issue.GroupedIssues, error) {
+	if demo {
+		return issue.NewGroupedIssues(demodata.IssueSearchResult(), "").WithSort(cfg.Issue.Sort).
+			WithClosedTabs(closedTabs(config.MergeIssueQueries(cfg.Issue.Queries))), nil
+	}
+
+	hosts := resolveHosts(cfg)
+	results, failed, err := fetchHosts(hosts, func(host string) (hostIssues, error) {
+		return fetchHostIssues(cfg, host)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	done := timing.Track("issue:merge-hosts")
+	issues := results[0].issues
+	logins := make(map[string]string, len(results))
+	for i, r := range results {
+		logins[r.host] = r.login
+		if i > 0 {
+			issues = gh.MergeSearchIssuesResults(issues, r.issues)
+		}
+	}
+	issues.Errors = withHostErrors(issues.Errors, failed, config.MergeIssueQueries(cfg.Issue.Queries))
+	done()
+	slog.Debug("rate limit", "cost", issues.RateLimit.Cost, "remaining", issues.RateLimit.Remaining, "limit", issues.RateLimit.Limit)
 
-			done = timing.Track("issue:merge-results")
-			issues := gh.MergeSearchIssuesResults(userResult.v, teamResult.v)
-			done()
+	done = timing.Track("issue:group")
+	grouped := issue.NewGroupedIssues(issues, results[0].login).WithHostLogins(logins).WithSort(cfg.Issue.Sort).
+		WithClosedTabs(closedTabs(config.MergeIssueQueries(cfg.Issue.Queries)))
+	done()
 
-			done = timing.Track("issue:group")
-			ig := issue.NewGroupedIssues(issues, username)
-			done()
+	saveSnapshot("issue", cfg, cfg.Issue, snapshot[*gh.IssueSearchResult]{Result: issues, Login: results[0].login, HostLogins: logins})
 
-			return ig.BuildTabs(), nil
-		})
+	return grouped, nil
+}
 
-		m := ui.NewLoadingModel(fetch)
-		p := tea.NewProgram(m, tea.WithAltScreen())
-		finalModel, err := p.Run()
+// loadIssues returns the cached results with --offline and fetches them otherwise.
+func loadIssues(cfg config.Config) (*issue.GroupedIssues, error) {
+	if offline {
+		ig, _, err := cachedIssues(cfg)
 		if err != nil {
-			return err
+			return nil, noCacheError("issue", err)
 		}
-		if fm, ok := finalModel.(ui.Model); ok {
-			if fmErr := fm.Err(); fmErr != nil {
-				return fmErr
-			}
+		return ig, nil
+	}
+	return fetchIssues(cfg)
+}
+
+// cachedIssues returns the results of the last successful fetch and when it happened.
+func cachedIssues(cfg config.Config) (*issue.GroupedIssues, time.Time, error) {
+	if demo {
+		return nil, time.Time{}, errors.New("no cache in demo mode")
+	}
+	snap, cachedAt, err := loadSnapshot[*gh.IssueSearchResult]("issue", cfg, cfg.Issue)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if snap.Result == nil {
+		return nil, time.Time{}, errors.New("empty result cache")
+	}
+	return issue.NewGroupedIssues(snap.Result, snap.Login).WithHostLogins(snap.HostLogins).WithSort(cfg.Issue.Sort).
+		WithClosedTabs(closedTabs(config.MergeIssueQueries(cfg.Issue.Queries))), cachedAt, nil
+}
+
+// hostIssues holds the search results of one host and the login they were resolved for.
+type hostIssues struct {
+	host   string
+	login  string
+	issues *gh.IssueSearchResult
+}
+
+// fetchHostIssues runs the user and team searches against a single host.
+func fetchHostIssues(cfg config.Config, host string) (hostIssues, error) {
+	done := timing.Track("issue:login")
+	username, err := gh.CurrentLoginForHost(host)
+	done()
+	if err != nil {
+		return hostIssues{}, err
+	}
+
+	entries := config.ResolveQueries(config.MergeIssueQueries(cfg.Issue.Queries), username)
+
+	done = timing.Track("issue:rest-client")
+	restClient, err := api.NewRESTClient(api.ClientOptions{Host: host})
+	done()
+	if err != nil {
+		return hostIssues{}, err
+	}
+
+	done = timing.Track("issue:graphql-client")
+	client, err := api.NewGraphQLClient(api.ClientOptions{Host: host})
+	done()
+	if err != nil {
+		return hostIssues{}, err
+	}
+
+	done = timing.Track("issue:cache-store")
+	store, err := cache.NewStoreForHost(host)
+	done()
+	if err != nil {
+		return hostIssues{}, err
+	}
+
+	userCh := make(chan result[*gh.IssueSearchResult], 1)
+	go func() {
+		defer timing.Track("issue:search-user")()
+		issues, err := gh.SearchIssues(client, entries, searchLimit(cfg.Issue))
+		userCh <- result[*gh.IssueSearchResult]{v: issues, err: err}
+	}()
+
+	teamCh := make(chan result[*gh.IssueSearchResult], 1)
+	go func() {
+		defer timing.Track("issue:search-teams-total")()
+
+		teamDone := timing.Track("issue:get-team-slugs")
+		teams, err := gh.GetTeamSlugsWithCache(restClient, store, 6*time.Hour)
+		teamDone()
+		if err != nil {
+			// Only the participated tab depends on teams; show it as failed
+			// rather than failing every tab.
+			teamCh <- result[*gh.IssueSearchResult]{v: &gh.IssueSearchResult{Errors: map[string]error{"participated": err}}}
+			return
 		}
-		return nil
-	},
+
+		teamDone = timing.Track("issue:search-teams")
+		issues, err := gh.SearchIssuesTeams(client, username, teams, searchLimit(cfg.Issue))
+		teamDone()
+		if err != nil {
+			teamCh <- result[*gh.IssueSearchResult]{v: nil, err: err}
+			return
+		}
+		teamCh <- result[*gh.IssueSearchResult]{v: issues, err: err}
+	}()
+
+	userResult := <-userCh
+	if userResult.err != nil {
+		return hostIssues{}, userResult.err
+	}
+
+	teamResult := <-teamCh
+	if teamResult.err != nil {
+		return hostIssues{}, teamResult.err
+	}
+
+	done = timing.Track("issue:merge-results")
+	issues := gh.MergeSearchIssuesResults(userResult.v, teamResult.v)
+	done()
+
+	return hostIssues{host: host, login: username, issues: issues}, nil
 }
@@ -0,0 +1,73 @@
+// Package cmd implements gh-own CLI subcommands.
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/snrsw/gh-own/internal/issue"
+	"github.com/snrsw/gh-own/internal/output"
+	"github.com/snrsw/gh-own/internal/pr"
+	"github.com/spf13/cobra"
+)
+
+var (
+	jsonFields   []string
+	jqExpr       string
+	templateText string
+)
+
+func exportRequested() bool {
+	return len(jsonFields) > 0 || jqExpr != "" || templateText != ""
+}
+
+func validateExportFields(available []string) error {
+	return output.ValidateFields(jsonFields, available)
+}
+
+// flagError lists the available fields when --json is given without any, like
+// gh does, and returns other flag errors as-is.
+func flagError(cmd *cobra.Command, err error) error {
+	if !strings.Contains(err.Error(), "flag needs an argument: --json") {
+		return err
+	}
+	cmd.SilenceUsage = true
+	fields := pr.ExportFields
+	if cmd.Name() == issueCmd.Name() {
+		fields = issue.ExportFields
+	}
+	return fmt.Errorf("specify one or more comma-separated fields for `--json`:\n  %s", strings.Join(fields, "\n  "))
+}
+
+func writeExport(groups []output.Group) error {
+	t := term.FromEnv()
+	width, _, err := t.Size()
+	if err != nil {
+		width = 80
+	}
+	return output.Write(os.Stdout, groups, output.Options{
+		Fields:   jsonFields,
+		JQ:       jqExpr,
+		Template: templateText,
+		Width:    width,
+		Color:    t.IsColorEnabled(),
+	})
+}
+
+// interactive reports whether stdout is a terminal that can host the interactive UI.
+func interactive() bool {
+	return term.FromEnv().IsTerminalOutput()
+}
+
+func writeTable(t output.Table) error {
+	return output.WriteTable(os.Stdout, t)
+}
+
+// warn reports tabs that failed to load on stderr, so partial output stays usable.
+func warn(warnings []string) {
+	for _, w := range warnings {
+		_, _ = fmt.Fprintf(os.Stderr, "warning: %s\n", w)
+	}
+}
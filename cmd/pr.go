@@ -2,9 +2,10 @@
 package cmd
 
 import (
+	"errors"
+	"log/slog"
 	"time"
 
-	tea "github.com/charmbracelet/bubbletea"
 	"github.com/cli/go-gh/v2/pkg/api"
 	"github.com/snrsw/gh-own/internal/cache"
 	"github.com/snrsw/gh-own/internal/config"
@@ -20,6 +21,7 @@ var prCmd = &cobra.Command{
 	Use:   "pr",
 	Short: "GitHub CLI extension to list your owned pull requests.",
 	Long:  "GitHub CLI extension to list your owned pull requests.",
+	Args:  cobra.NoArgs,
 	RunE: func(_ *cobra.Command, _ []string) error {
 		defer timing.Track("pr:total")()
 
@@ -30,103 +32,205 @@ var prCmd = &cobra.Command{
 			return cfgErr
 		}
 
-		fetch := ui.FetchCmd(func() ([]ui.Tab, error) {
-			if demo {
-				prg := pr.NewGroupedPullRequests(demodata.PRSearchResult(), "")
-				return prg.BuildTabs(), nil
+		if exportRequested() {
+			if err := validateExportFields(pr.ExportFields); err != nil {
+				return err
 			}
-
-			done := timing.Track("pr:login")
-			username, err := gh.CurrentLogin()
-			done()
+			prg, err := loadPullRequests(cfg)
 			if err != nil {
-				return nil, err
+				return err
 			}
+			warn(prg.Warnings())
+			return writeExport(prg.Export())
+		}
 
-			entries := config.ResolveQueries(config.MergePRQueries(cfg.PR.Queries), username)
-
-			done = timing.Track("pr:rest-client")
-			restClient, err := api.DefaultRESTClient()
-			done()
+		if !interactive() {
+			prg, err := loadPullRequests(cfg)
 			if err != nil {
-				return nil, err
+				return err
 			}
+			warn(prg.Warnings())
+			return writeTable(prg.Table())
+		}
 
-			done = timing.Track("pr:graphql-client")
-			client, err := api.DefaultGraphQLClient()
-			done()
+		if offline {
+			prg, cachedAt, err := cachedPullRequests(cfg)
 			if err != nil {
-				return nil, err
+				return noCacheError("pr", err)
 			}
+			return runUI(ui.NewModel(prg.BuildTabs()).WithInfo(offlineInfo(cachedAt)))
+		}
 
-			done = timing.Track("pr:cache-store")
-			store, err := cache.NewStore()
-			done()
+		fetch := ui.FetchWithInfoCmd(func() ([]ui.Tab, string, error) {
+			prg, err := fetchPullRequests(cfg)
 			if err != nil {
-				return nil, err
+				return nil, "", err
 			}
+			return prg.BuildTabs(), prg.RateLimit.String(), nil
+		})
 
-			userCh := make(chan result[*gh.PRSearchResult], 1)
-			go func() {
-				defer timing.Track("pr:search-user")()
-				prs, err := gh.SearchPRs(client, entries)
-				userCh <- result[*gh.PRSearchResult]{v: prs, err: err}
-			}()
-
-			teamCh := make(chan result[*gh.PRSearchResult], 1)
-			go func() {
-				defer timing.Track("pr:search-teams-total")()
-
-				teamDone := timing.Track("pr:get-team-slugs")
-				teams, err := gh.GetTeamSlugsWithCache(restClient, store, 6*time.Hour)
-				teamDone()
-				if err != nil {
-					teamCh <- result[*gh.PRSearchResult]{v: nil, err: err}
-					return
-				}
-
-				teamDone = timing.Track("pr:search-teams")
-				prs, err := gh.SearchPRsTeams(client, username, teams)
-				teamDone()
-				if err != nil {
-					teamCh <- result[*gh.PRSearchResult]{v: nil, err: err}
-					return
-				}
-				teamCh <- result[*gh.PRSearchResult]{v: prs, err: err}
-			}()
-
-			userResult := <-userCh
-			if userResult.err != nil {
-				return nil, userResult.err
-			}
+		m := ui.NewLoadingModel(fetch)
+		if prg, cachedAt, err := cachedPullRequests(cfg); err == nil {
+			m = ui.NewStaleModel(prg.BuildTabs(), cachedAt, fetch)
+		}
+		if !demo {
+			m = m.WithActions(pr.Actions(graphQLClient)...).
+				WithActions(pr.ReviewersAction(graphQLClient, teamSlugs), pr.CheckoutAction(cfg.Checkout)).
+				WithDetail(pr.Detail(graphQLClient)).
+				WithEditor(gh.Editor())
+		}
+		return runUI(m.WithAutoRefresh(refreshInterval(cfg)))
+	},
+}
 
-			teamResult := <-teamCh
-			if teamResult.err != nil {
-				return nil, teamResult.err
-			}
+func fetchPullRequests(cfg config.Config) (*pr.GroupedPullRequests, error) {
+	if demo {
+		return pr.NewGroupedPullRequests(demodata.PRSearchResult(), "").WithSort(cfg.PR.Sort).
+			WithClosedTabs(closedTabs(config.MergePRQueries(cfg.PR.Queries))), nil
+	}
+
+	hosts := resolveHosts(cfg)
+	results, failed, err := fetchHosts(hosts, func(host string) (hostPullRequests, error) {
+		return fetchHostPullRequests(cfg, host)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	done := timing.Track("pr:merge-hosts")
+	prs := results[0].prs
+	logins := make(map[string]string, len(results))
+	for i, r := range results {
+		logins[r.host] = r.login
+		if i > 0 {
+			prs = gh.MergeSearchPRsResults(prs, r.prs)
+		}
+	}
+	prs.Errors = withHostErrors(prs.Errors, failed, config.MergePRQueries(cfg.PR.Queries))
+	done()
+	slog.Debug("rate limit", "cost", prs.RateLimit.Cost, "remaining", prs.RateLimit.Remaining, "limit", prs.RateLimit.Limit)
 
-			done = timing.Track("pr:merge-results")
-			prs := gh.MergeSearchPRsResults(userResult.v, teamResult.v)
-			done()
+	done = timing.Track("pr:group")
+	grouped := pr.NewGroupedPullRequests(prs, results[0].login).WithHostLogins(logins).WithSort(cfg.PR.Sort).
+		WithClosedTabs(closedTabs(config.MergePRQueries(cfg.PR.Queries)))
+	done()
 
-			done = timing.Track("pr:group")
-			prg := pr.NewGroupedPullRequests(prs, username)
-			done()
+	saveSnapshot("pr", cfg, cfg.PR, snapshot[*gh.PRSearchResult]{Result: prs, Login: results[0].login, HostLogins: logins})
 
-			return prg.BuildTabs(), nil
-		})
+	return grouped, nil
+}
 
-		m := ui.NewLoadingModel(fetch)
-		p := tea.NewProgram(m, tea.WithAltScreen())
-		finalModel, err := p.Run()
+// loadPullRequests returns the cached results with --offline and fetches them otherwise.
+func loadPullRequests(cfg config.Config) (*pr.GroupedPullRequests, error) {
+	if offline {
+		prg, _, err := cachedPullRequests(cfg)
 		if err != nil {
-			return err
+			return nil, noCacheError("pr", err)
 		}
-		if fm, ok := finalModel.(ui.Model); ok {
-			if fmErr := fm.Err(); fmErr != nil {
-				return fmErr
-			}
+		return prg, nil
+	}
+	return fetchPullRequests(cfg)
+}
+
+// cachedPullRequests returns the results of the last successful fetch and when it happened.
+func cachedPullRequests(cfg config.Config) (*pr.GroupedPullRequests, time.Time, error) {
+	if demo {
+		return nil, time.Time{}, errors.New("no cache in demo mode")
+	}
+	snap, cachedAt, err := loadSnapshot[*gh.PRSearchResult]("pr", cfg, cfg.PR)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if snap.Result == nil {
+		return nil, time.Time{}, errors.New("empty result cache")
+	}
+	return pr.NewGroupedPullRequests(snap.Result, snap.Login).WithHostLogins(snap.HostLogins).WithSort(cfg.PR.Sort).
+		WithClosedTabs(closedTabs(config.MergePRQueries(cfg.PR.Queries))), cachedAt, nil
+}
+
+// hostPullRequests holds the search results of one host and the login they were resolved for.
+type hostPullRequests struct {
+	host  string
+	login string
+	prs   *gh.PRSearchResult
+}
+
+// fetchHostPullRequests runs the user and team searches against a single host.
+func fetchHostPullRequests(cfg config.Config, host string) (hostPullRequests, error) {
+	done := timing.Track("pr:login")
+	username, err := gh.CurrentLoginForHost(host)
+	done()
+	if err != nil {
+		return hostPullRequests{}, err
+	}
+
+	entries := config.ResolveQueries(config.MergePRQueries(cfg.PR.Queries), username)
+
+	done = timing.Track("pr:rest-client")
+	restClient, err := api.NewRESTClient(api.ClientOptions{Host: host})
+	done()
+	if err != nil {
+		return hostPullRequests{}, err
+	}
+
+	done = timing.Track("pr:graphql-client")
+	client, err := api.NewGraphQLClient(api.ClientOptions{Host: host})
+	done()
+	if err != nil {
+		return hostPullRequests{}, err
+	}
+
+	done = timing.Track("pr:cache-store")
+	store, err := cache.NewStoreForHost(host)
+	done()
+	if err != nil {
+		return hostPullRequests{}, err
+	}
+
+	userCh := make(chan result[*gh.PRSearchResult], 1)
+	go func() {
+		defer timing.Track("pr:search-user")()
+		prs, err := gh.SearchPRs(client, entries, searchLimit(cfg.PR))
+		userCh <- result[*gh.PRSearchResult]{v: prs, err: err}
+	}()
+
+	teamCh := make(chan result[*gh.PRSearchResult], 1)
+	go func() {
+		defer timing.Track("pr:search-teams-total")()
+
+		teamDone := timing.Track("pr:get-team-slugs")
+		teams, err := gh.GetTeamSlugsWithCache(restClient, store, 6*time.Hour)
+		teamDone()
+		if err != nil {
+			// Only the participated tab depends on teams; show it as failed
+			// rather than failing every tab.
+			teamCh <- result[*gh.PRSearchResult]{v: &gh.PRSearchResult{Errors: map[string]error{"participated": err}}}
+			return
 		}
-		return nil
-	},
+
+		teamDone = timing.Track("pr:search-teams")
+		prs, err := gh.SearchPRsTeams(client, username, teams, searchLimit(cfg.PR))
+		teamDone()
+		if err != nil {
+			teamCh <- result[*gh.PRSearchResult]{v: nil, err: err}
+			return
+		}
+		teamCh <- result[*gh.PRSearchResult]{v: prs, err: err}
+	}()
+
+	userResult := <-userCh
+	if userResult.err != nil {
+		return hostPullRequests{}, userResult.err
+	}
+
+	teamResult := <-teamCh
+	if teamResult.err != nil {
+		return hostPullRequests{}, teamResult.err
+	}
+
+	done = timing.Track("pr:merge-results")
+	prs := gh.MergeSearchPRsResults(userResult.v, teamResult.v)
+	done()
+
+	return hostPullRequests{host: host, login: username, prs: prs}, nil
 }
@@ -5,7 +5,12 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strings"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/snrsw/gh-own/internal/config"
+	"github.com/snrsw/gh-own/internal/ui"
 	"github.com/spf13/cobra"
 )
 
@@ -26,11 +31,28 @@ var rootCmd = &cobra.Command{
 		}
 		return nil
 	},
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return prCmd.RunE(cmd, args)
 	},
 }
 
+// runUI runs the interactive UI until the user quits, returning the error that
+// ended it, if any.
+func runUI(m ui.Model) error {
+	p := tea.NewProgram(m, tea.WithAltScreen())
+	finalModel, err := p.Run()
+	if err != nil {
+		return err
+	}
+	if fm, ok := finalModel.(ui.Model); ok {
+		if fmErr := fm.Err(); fmErr != nil {
+			return fmErr
+		}
+	}
+	return nil
+}
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "%v\n", err)
@@ -40,9 +62,65 @@ func Execute() {
 
 var debug bool
 var demo bool
+var offline bool
+var limit int
+var watch time.Duration
+
+// defaultWatchInterval is used when --watch is given without an interval.
+const defaultWatchInterval = "5m"
+
+// minRefreshInterval keeps auto-refresh from eating into the search rate limit.
+const minRefreshInterval = 30 * time.Second
+
+// searchLimit returns the per-tab result limit, preferring --limit over the config file.
+func searchLimit(cc config.CommandConfig) int {
+	if limit > 0 {
+		return limit
+	}
+	return cc.Limit
+}
+
+// closedTabs returns the keys of the tabs whose queries may match closed
+// items, so closing an item keeps it there. The participated* searches share
+// the "participated" tab.
+func closedTabs(queries map[string]string) map[string]bool {
+	closed := make(map[string]bool, len(queries))
+	for key, query := range queries {
+		if strings.HasPrefix(key, "participated") {
+			key = "participated"
+		}
+		closed[key] = closed[key] || !config.OpenOnly(query)
+	}
+	return closed
+}
+
+// refreshInterval returns the auto-refresh interval, preferring --watch over
+// the config file. Zero means auto-refresh is off.
+func refreshInterval(cfg config.Config) time.Duration {
+	interval := watch
+	if interval <= 0 {
+		interval = cfg.RefreshInterval
+	}
+	if interval <= 0 {
+		return 0
+	}
+	return max(interval, minRefreshInterval)
+}
 
 func init() {
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "enable debug logging")
 	rootCmd.PersistentFlags().BoolVar(&demo, "demo", false, "use demo data (no GitHub API calls)")
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "show the results cached by the last run without any network calls")
+	rootCmd.PersistentFlags().StringSliceVar(&hostnames, "hostname", nil, "GitHub `host` to query; repeat to query several hosts (default: gh default host)")
+	rootCmd.PersistentFlags().DurationVar(&watch, "watch", 0, "refetch results in the background every `interval` and highlight changes (default 5m if no interval is given)")
+	rootCmd.PersistentFlags().Lookup("watch").NoOptDefVal = defaultWatchInterval
+	rootCmd.PersistentFlags().IntVarP(&limit, "limit", "L", 0, "maximum number of results to fetch per tab (default 50)")
+	rootCmd.PersistentFlags().StringSliceVar(&jsonFields, "json", nil, "output JSON with the specified `fields` instead of the interactive UI")
+	rootCmd.PersistentFlags().StringVarP(&jqExpr, "jq", "q", "", "filter JSON output using a jq `expression`")
+	rootCmd.PersistentFlags().StringVarP(&templateText, "template", "t", "", "format JSON output using a Go `template`")
+	rootCmd.MarkFlagsMutuallyExclusive("jq", "template")
+	rootCmd.MarkFlagsMutuallyExclusive("offline", "demo")
+	rootCmd.MarkFlagsMutuallyExclusive("offline", "watch")
+	rootCmd.SetFlagErrorFunc(flagError)
 	rootCmd.AddCommand(prCmd, issueCmd)
 }
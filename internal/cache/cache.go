@@ -24,10 +24,61 @@ func NewStore() (*Store, error) {
 	return &Store{path: path}, nil
 }
 
+// NewStoreForHost returns a store for the teams of host. github.com keeps the
+// original teams.json so existing caches stay valid.
+func NewStoreForHost(host string) (*Store, error) {
+	if host == "" || host == "github.com" {
+		return NewStore()
+	}
+	cacheDir := config.CacheDir()
+	path := filepath.Join(cacheDir, "gh-own", "teams-"+host+".json")
+	return &Store{path: path}, nil
+}
+
+// NewResultStore returns a store for the search results identified by key.
+func NewResultStore(key string) (*Store, error) {
+	cacheDir := config.CacheDir()
+	path := filepath.Join(cacheDir, "gh-own", "results", key+".json")
+	return &Store{path: path}, nil
+}
+
 func NewStoreWithPath(path string) *Store {
 	return &Store{path: path}
 }
 
+// entry is the on-disk form of a value written with Write.
+type entry struct {
+	Data     json.RawMessage `json:"data"`
+	CachedAt time.Time       `json:"cached_at"`
+}
+
+// Read decodes the value last stored with Write into v and returns when it
+// was stored. The error wraps fs.ErrNotExist if nothing was stored yet.
+func (s *Store) Read(v any) (time.Time, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return time.Time{}, err
+	}
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return time.Time{}, err
+	}
+	return e.CachedAt, nil
+}
+
+// Write stores v as JSON together with the current time.
+func (s *Store) Write(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.writeJSON(entry{Data: data, CachedAt: time.Now()})
+}
+
 func (s *Store) ReadTeams(ttl time.Duration) ([]string, error) {
 	data, err := os.ReadFile(s.path)
 	if err != nil {
@@ -47,17 +98,20 @@ func (s *Store) ReadTeams(ttl time.Duration) ([]string, error) {
 }
 
 func (s *Store) WriteTeams(teams []string) error {
+	return s.writeJSON(TeamCache{
+		Teams:    teams,
+		CachedAt: time.Now(),
+	})
+}
+
+// writeJSON atomically replaces the store file with v encoded as JSON.
+func (s *Store) writeJSON(v any) error {
 	dir := filepath.Dir(s.path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return err
 	}
 
-	cache := TeamCache{
-		Teams:    teams,
-		CachedAt: time.Now(),
-	}
-
-	data, err := json.Marshal(cache)
+	data, err := json.Marshal(v)
 	if err != nil {
 		return err
 	}
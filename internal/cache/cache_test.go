@@ -2,8 +2,11 @@ package cache
 
 import (
 	"encoding/json"
+	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -23,6 +26,26 @@ func TestNewStore_PathSuffix(t *testing.T) {
 	}
 }
 
+func TestNewStoreForHost_PathSuffix(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"github.com", filepath.Join("gh-own", "teams.json")},
+		{"", filepath.Join("gh-own", "teams.json")},
+		{"ghe.example.com", filepath.Join("gh-own", "teams-ghe.example.com.json")},
+	}
+	for _, tt := range tests {
+		store, err := NewStoreForHost(tt.host)
+		if err != nil {
+			t.Fatalf("NewStoreForHost(%q) error: %v", tt.host, err)
+		}
+		if got := store.path; !strings.HasSuffix(got, tt.want) {
+			t.Errorf("NewStoreForHost(%q).path = %q, want suffix %q", tt.host, got, tt.want)
+		}
+	}
+}
+
 func TestIsExpired_WithinTTL(t *testing.T) {
 	cachedAt := time.Now().Add(-1 * time.Hour)
 	ttl := 6 * time.Hour
@@ -184,3 +207,63 @@ func TestReadTeams_InvalidJSON(t *testing.T) {
 		t.Errorf("ReadTeams() = %v, want nil (cache miss due to invalid JSON)", teams)
 	}
 }
+
+func TestNewResultStore_PathSuffix(t *testing.T) {
+	store, err := NewResultStore("pr-abc123")
+	if err != nil {
+		t.Fatalf("NewResultStore() error: %v", err)
+	}
+
+	want := filepath.Join("gh-own", "results", "pr-abc123.json")
+	if !strings.HasSuffix(store.path, want) {
+		t.Errorf("NewResultStore().path = %q, want suffix %q", store.path, want)
+	}
+}
+
+func TestReadWrite_RoundTrip(t *testing.T) {
+	store := NewStoreWithPath(filepath.Join(t.TempDir(), "results", "pr.json"))
+
+	type value struct {
+		Login string         `json:"login"`
+		Tabs  map[string]int `json:"tabs"`
+	}
+	want := value{Login: "me", Tabs: map[string]int{"created": 3}}
+
+	before := time.Now()
+	if err := store.Write(want); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	var got value
+	cachedAt, err := store.Read(&got)
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+	if got.Login != want.Login || got.Tabs["created"] != 3 {
+		t.Errorf("Read() = %+v, want %+v", got, want)
+	}
+	if cachedAt.Before(before) {
+		t.Errorf("cachedAt = %v, want at or after %v", cachedAt, before)
+	}
+}
+
+func TestRead_MissingFile(t *testing.T) {
+	store := NewStoreWithPath(filepath.Join(t.TempDir(), "missing.json"))
+
+	var v map[string]any
+	if _, err := store.Read(&v); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Read() error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestRead_CorruptedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	var v map[string]any
+	if _, err := NewStoreWithPath(path).Read(&v); err == nil {
+		t.Error("Read() of corrupted file should return an error")
+	}
+}
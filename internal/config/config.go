@@ -2,21 +2,45 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/snrsw/gh-own/internal/sortmode"
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	PR    CommandConfig `yaml:"pr"`
-	Issue CommandConfig `yaml:"issue"`
+	// Hosts lists the GitHub hosts to query. Empty means the gh default host.
+	Hosts []string `yaml:"hosts"`
+	// RefreshInterval is how often the interactive UI refetches results in
+	// the background, e.g. "5m". Zero disables auto-refresh.
+	RefreshInterval time.Duration `yaml:"refresh_interval"`
+	PR              CommandConfig `yaml:"pr"`
+	Issue           CommandConfig `yaml:"issue"`
+	// Checkout configures checking out pull requests from the interactive UI.
+	Checkout CheckoutConfig `yaml:"checkout"`
+}
+
+type CheckoutConfig struct {
+	// Repos maps repositories, e.g. "owner/repo" or "ghe.example.com/owner/repo"
+	// for hosts other than github.com, to the path of a local clone.
+	Repos map[string]string `yaml:"repos"`
+	// WorktreeRoot is the directory pull requests are checked out under, each
+	// in its own git worktree. Empty checks them out in the clone itself.
+	WorktreeRoot string `yaml:"worktree_root"`
 }
 
 type CommandConfig struct {
 	Queries map[string]string `yaml:"queries"`
+	// Limit is the maximum number of results fetched per tab. Zero means the default.
+	Limit int `yaml:"limit"`
+	// Sort maps tab keys to the order their items are shown in. Tabs not
+	// listed keep the order the search returned.
+	Sort map[string]sortmode.SortMode `yaml:"sort"`
 }
 
 func DefaultPath() string {
@@ -51,10 +75,32 @@ func LoadFromPath(path string) (Config, error) {
 	if cfg.Issue.Queries != nil {
 		cfg.Issue.Queries = NormalizeKeys(cfg.Issue.Queries)
 	}
+	if cfg.PR.Sort, err = normalizeSort("pr", cfg.PR.Sort, sortmode.PRModes); err != nil {
+		return Config{}, err
+	}
+	if cfg.Issue.Sort, err = normalizeSort("issue", cfg.Issue.Sort, sortmode.IssueModes); err != nil {
+		return Config{}, err
+	}
+	for repo, path := range cfg.Checkout.Repos {
+		cfg.Checkout.Repos[repo] = expandHome(path)
+	}
+	cfg.Checkout.WorktreeRoot = expandHome(cfg.Checkout.WorktreeRoot)
 
 	return cfg, nil
 }
 
+// expandHome replaces a leading "~" in path with the home directory.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
+
 var defaultPRQueries = map[string]string{
 	"created":          "is:pr is:open author:{user}",
 	"assigned":         "is:pr is:open assignee:{user}",
@@ -136,6 +182,25 @@ func NormalizeKeys(queries map[string]string) map[string]string {
 	return normalized
 }
 
+// normalizeSort resolves the key aliases of the sort config of command and
+// checks that every mode is one of modes.
+func normalizeSort(command string, sort map[string]sortmode.SortMode, modes []sortmode.SortMode) (map[string]sortmode.SortMode, error) {
+	if sort == nil {
+		return nil, nil
+	}
+	normalized := make(map[string]sortmode.SortMode, len(sort))
+	for k, mode := range sort {
+		if _, err := sortmode.Parse(string(mode), modes); err != nil {
+			return nil, fmt.Errorf("%s.sort.%s: %w", command, k, err)
+		}
+		if k == "review_requested" {
+			k = "reviewRequested"
+		}
+		normalized[k] = mode
+	}
+	return normalized, nil
+}
+
 func ResolveQueries(queries map[string]string, username string) map[string]string {
 	resolved := make(map[string]string, len(queries))
 	for key, query := range queries {
@@ -143,3 +208,14 @@ func ResolveQueries(queries map[string]string, username string) map[string]strin
 	}
 	return resolved
 }
+
+// OpenOnly reports whether query matches only open items, i.e. it has an
+// is:open or state:open qualifier.
+func OpenOnly(query string) bool {
+	for _, term := range strings.Fields(query) {
+		if strings.EqualFold(term, "is:open") || strings.EqualFold(term, "state:open") {
+			return true
+		}
+	}
+	return false
+}
@@ -2,8 +2,12 @@ package config
 
 import (
 	"os"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/snrsw/gh-own/internal/sortmode"
 )
 
 func TestDefaultPRKeys_ReturnsKnownKeys(t *testing.T) {
@@ -109,6 +113,25 @@ func TestResolveQueries_MultipleOccurrences(t *testing.T) {
 	}
 }
 
+func TestOpenOnly(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"is:pr is:open author:{user}", true},
+		{"is:issue state:open label:bug", true},
+		{"is:pr IS:OPEN", true},
+		{"is:pr is:closed author:{user}", false},
+		{"is:pr author:{user}", false},
+		{"is:pr -is:open", false},
+	}
+	for _, tt := range tests {
+		if got := OpenOnly(tt.query); got != tt.want {
+			t.Errorf("OpenOnly(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
 func TestResolveQueries_NoPlaceholder(t *testing.T) {
 	queries := map[string]string{
 		"custom": "is:pr is:open label:bug",
@@ -322,6 +345,58 @@ issue:
 	}
 }
 
+func TestLoadFromPath_ValidYAML_ParsesLimit(t *testing.T) {
+	content := `
+pr:
+  limit: 200
+`
+	path := writeTempYAML(t, content)
+
+	cfg, err := LoadFromPath(path)
+	if err != nil {
+		t.Fatalf("LoadFromPath returned error: %v", err)
+	}
+
+	if cfg.PR.Limit != 200 {
+		t.Errorf("PR.Limit = %d, want 200", cfg.PR.Limit)
+	}
+	if cfg.Issue.Limit != 0 {
+		t.Errorf("Issue.Limit = %d, want 0", cfg.Issue.Limit)
+	}
+}
+
+func TestLoadFromPath_ValidYAML_ParsesHosts(t *testing.T) {
+	content := `
+hosts:
+  - github.com
+  - ghe.example.com
+`
+	path := writeTempYAML(t, content)
+
+	cfg, err := LoadFromPath(path)
+	if err != nil {
+		t.Fatalf("LoadFromPath returned error: %v", err)
+	}
+
+	want := []string{"github.com", "ghe.example.com"}
+	if !reflect.DeepEqual(cfg.Hosts, want) {
+		t.Errorf("Hosts = %v, want %v", cfg.Hosts, want)
+	}
+}
+
+func TestLoadFromPath_ValidYAML_ParsesRefreshInterval(t *testing.T) {
+	path := writeTempYAML(t, "refresh_interval: 5m\n")
+
+	cfg, err := LoadFromPath(path)
+	if err != nil {
+		t.Fatalf("LoadFromPath returned error: %v", err)
+	}
+
+	if cfg.RefreshInterval != 5*time.Minute {
+		t.Errorf("RefreshInterval = %v, want 5m", cfg.RefreshInterval)
+	}
+}
+
 func TestLoadFromPath_InvalidYAML_ReturnsError(t *testing.T) {
 	path := writeTempYAML(t, "{{invalid yaml")
 
@@ -385,3 +460,61 @@ func TestDefaultPath_FallsBackToHomeDotConfig(t *testing.T) {
 		t.Errorf("DefaultPath() = %q, want %q", got, want)
 	}
 }
+
+func TestLoadFromPath_ValidYAML_ParsesCheckout(t *testing.T) {
+	t.Setenv("HOME", "/home/alice")
+	path := writeTempYAML(t, `checkout:
+  repos:
+    owner/repo: ~/src/repo
+    other/repo: /srv/other
+  worktree_root: ~/worktrees
+`)
+
+	cfg, err := LoadFromPath(path)
+	if err != nil {
+		t.Fatalf("LoadFromPath returned error: %v", err)
+	}
+
+	want := map[string]string{"owner/repo": "/home/alice/src/repo", "other/repo": "/srv/other"}
+	if !reflect.DeepEqual(cfg.Checkout.Repos, want) {
+		t.Errorf("Checkout.Repos = %v, want %v", cfg.Checkout.Repos, want)
+	}
+	if cfg.Checkout.WorktreeRoot != "/home/alice/worktrees" {
+		t.Errorf("Checkout.WorktreeRoot = %q, want /home/alice/worktrees", cfg.Checkout.WorktreeRoot)
+	}
+}
+
+func TestLoadFromPath_ValidYAML_ParsesSort(t *testing.T) {
+	path := writeTempYAML(t, `pr:
+  sort:
+    created: updated
+    review_requested: ci
+issue:
+  sort:
+    assigned: activity
+`)
+
+	cfg, err := LoadFromPath(path)
+	if err != nil {
+		t.Fatalf("LoadFromPath returned error: %v", err)
+	}
+
+	wantPR := map[string]sortmode.SortMode{"created": sortmode.SortModeUpdated, "reviewRequested": sortmode.SortModeCI}
+	if !reflect.DeepEqual(cfg.PR.Sort, wantPR) {
+		t.Errorf("PR.Sort = %v, want %v", cfg.PR.Sort, wantPR)
+	}
+	if cfg.Issue.Sort["assigned"] != sortmode.SortModeActivity {
+		t.Errorf("Issue.Sort[assigned] = %q, want activity", cfg.Issue.Sort["assigned"])
+	}
+}
+
+func TestLoadFromPath_InvalidSort_ReturnsError(t *testing.T) {
+	path := writeTempYAML(t, `issue:
+  sort:
+    created: ci
+`)
+
+	if _, err := LoadFromPath(path); err == nil || !strings.Contains(err.Error(), "issue.sort.created") {
+		t.Errorf("LoadFromPath error = %v, want one naming issue.sort.created", err)
+	}
+}
@@ -15,20 +15,20 @@ func PRSearchResult() *gh.PRSearchResult {
 				"acme-corp/frontend", false, "SUCCESS", "APPROVED",
 				gh.LatestActivity{Kind: "approved", Login: "alice", At: "2026-03-06T14:30:00Z"},
 				"bob", "2026-03-01T09:00:00Z"),
-			prNode(42, "fix: resolve memory leak in worker",
+			withMergeState(prNode(42, "fix: resolve memory leak in worker",
 				"acme-corp/backend", false, "FAILURE", "",
 				gh.LatestActivity{Kind: "commented", Login: "carol", At: "2026-03-05T11:20:00Z"},
-				"bob", "2026-02-25T08:00:00Z"),
+				"bob", "2026-02-25T08:00:00Z"), "CONFLICTING", "DIRTY"),
 			prNode(7, "chore: update CI configuration",
 				"demo-org/api-gateway", true, "PENDING", "REVIEW_REQUIRED",
 				gh.LatestActivity{},
 				"bob", "2026-03-03T16:00:00Z"),
 		},
 		Assigned: []gh.PRSearchNode{
-			prNode(88, "refactor: extract auth middleware",
+			withMergeState(prNode(88, "refactor: extract auth middleware",
 				"acme-corp/backend", false, "SUCCESS", "CHANGES_REQUESTED",
 				gh.LatestActivity{Kind: "changes requested", Login: "dave", At: "2026-03-06T09:00:00Z"},
-				"alice", "2026-02-28T10:00:00Z"),
+				"alice", "2026-02-28T10:00:00Z"), "MERGEABLE", "BEHIND"),
 		},
 		ReviewRequested: []gh.PRSearchNode{
 			prNode(55, "docs: add API usage examples",
@@ -97,6 +97,13 @@ func prNode(num int, title, repo string, draft bool, ci, review string,
 	return n
 }
 
+// withMergeState returns n with the given mergeable and mergeStateStatus.
+func withMergeState(n gh.PRSearchNode, mergeable, state string) gh.PRSearchNode {
+	n.Mergeable = mergeable
+	n.MergeStateStatus = state
+	return n
+}
+
 func issueNode(num int, title, repo, state string,
 	activity gh.LatestActivity, author, createdAt string) gh.IssueSearchNode {
 	updatedAt := activity.At
@@ -0,0 +1,124 @@
+// Package detail renders the detail view of a pull request or issue.
+package detail
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cli/go-gh/v2/pkg/markdown"
+	"github.com/snrsw/gh-own/internal/cistatus"
+	"github.com/snrsw/gh-own/internal/gh"
+	"github.com/snrsw/gh-own/internal/ui"
+)
+
+var (
+	titleStyle   = lipgloss.NewStyle().Bold(true)
+	headingStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.AdaptiveColor{Light: "#57606A", Dark: "#8B949E"})
+	mutedStyle   = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#6E7781", Dark: "#6E7681"})
+)
+
+// Render renders d under title, wrapped to width. currentLogin is not
+// highlighted among users.
+func Render(title string, d *gh.Detail, width int, currentLogin string) string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Width(width).Render(title) + "\n\n")
+
+	writeField(&b, "Labels", d.Labels, width)
+	writeField(&b, "Assignees", users(d.Assignees, currentLogin), width)
+	writeField(&b, "Reviewers", users(d.ReviewRequests, currentLogin), width)
+
+	writeChecks(&b, d.Checks)
+
+	b.WriteString("\n" + renderMarkdown(d.Body, width))
+
+	if len(d.Comments) > 0 {
+		b.WriteString("\n" + headingStyle.Render("Latest comments") + "\n")
+		for _, c := range d.Comments {
+			b.WriteString("\n" + ui.RenderUser(c.Author, currentLogin) + mutedStyle.Render(" · "+ui.UpdatedAgo(c.CreatedAt)) + "\n")
+			b.WriteString(renderMarkdown(c.Body, width))
+		}
+	}
+	return b.String()
+}
+
+// writeChecks writes the CI checks, failed ones first, with how long each took.
+func writeChecks(b *strings.Builder, checks []gh.Check) {
+	if len(checks) == 0 {
+		return
+	}
+	sorted := slices.Clone(checks)
+	slices.SortStableFunc(sorted, func(x, y gh.Check) int {
+		switch {
+		case x.Failed() == y.Failed():
+			return 0
+		case x.Failed():
+			return -1
+		default:
+			return 1
+		}
+	})
+
+	heading := "Checks"
+	if failed := countFailed(checks); failed > 0 {
+		heading += fmt.Sprintf(" (%d of %d failed)", failed, len(checks))
+	}
+	b.WriteString("\n" + headingStyle.Render(heading) + "\n")
+	for _, c := range sorted {
+		line := cistatus.RenderCIStatus(cistatus.ParseState(c.State)) + " " + c.Name
+		if d := c.Duration(); d > 0 {
+			line += mutedStyle.Render(" · " + d.Round(time.Second).String())
+		}
+		b.WriteString(line + "\n")
+	}
+}
+
+func countFailed(checks []gh.Check) int {
+	n := 0
+	for _, c := range checks {
+		if c.Failed() {
+			n++
+		}
+	}
+	return n
+}
+
+// writeField writes a "Name: a, b" line wrapped to width, if values is not empty.
+func writeField(b *strings.Builder, name string, values []string, width int) {
+	if len(values) == 0 {
+		return
+	}
+	line := headingStyle.Render(name+":") + " " + strings.Join(values, ", ")
+	b.WriteString(lipgloss.NewStyle().Width(width).Render(line) + "\n")
+}
+
+// users renders logins as mentions. Teams, given as "org/slug", are kept as-is.
+func users(logins []string, currentLogin string) []string {
+	rendered := make([]string, len(logins))
+	for i, l := range logins {
+		if strings.Contains(l, "/") {
+			rendered[i] = l
+			continue
+		}
+		rendered[i] = ui.RenderUser(l, currentLogin)
+	}
+	return rendered
+}
+
+// renderMarkdown renders body as markdown, falling back to plain text.
+func renderMarkdown(body string, width int) string {
+	if strings.TrimSpace(body) == "" {
+		return mutedStyle.Render("No description provided.") + "\n"
+	}
+	theme := "light"
+	if lipgloss.HasDarkBackground() {
+		theme = "dark"
+	}
+	out, err := markdown.Render(body, markdown.WithTheme(theme), markdown.WithWrap(width), markdown.WithoutIndentation())
+	if err != nil {
+		return lipgloss.NewStyle().Width(width).Render(body) + "\n"
+	}
+	return strings.Trim(out, "\n") + "\n"
+}
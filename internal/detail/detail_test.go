@@ -0,0 +1,70 @@
+package detail
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/snrsw/gh-own/internal/gh"
+)
+
+func TestRender(t *testing.T) {
+	d := &gh.Detail{
+		Body:           "Fixes the **flaky** test.",
+		Labels:         []string{"bug", "ci"},
+		Assignees:      []string{"alice"},
+		ReviewRequests: []string{"bob", "org/team"},
+		Checks: []gh.Check{
+			{Name: "build", State: "SUCCESS"},
+			{Name: "lint", State: "FAILURE", StartedAt: "2024-01-01T00:00:00Z", CompletedAt: "2024-01-01T00:02:13Z"},
+		},
+		Comments: []gh.Comment{{Author: "carol", CreatedAt: "2024-01-01T00:00:00Z", Body: "LGTM"}},
+	}
+
+	got := ansi.Strip(Render("owner/repo #12 Fix bug", d, 80, "alice"))
+
+	for _, want := range []string{
+		"owner/repo #12 Fix bug",
+		"Labels: bug, ci",
+		"Assignees: @alice",
+		"Reviewers: @bob, org/team",
+		"Checks (1 of 2 failed)",
+		"lint · 2m13s",
+		"flaky",
+		"Latest comments",
+		"@carol",
+		"LGTM",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Render() missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestRender_FailedChecksFirst(t *testing.T) {
+	d := &gh.Detail{Checks: []gh.Check{
+		{Name: "build", State: "SUCCESS"},
+		{Name: "lint", State: "FAILURE"},
+		{Name: "test", State: "ERROR"},
+	}}
+
+	got := ansi.Strip(Render("owner/repo #12 Fix bug", d, 80, ""))
+
+	lint, test, build := strings.Index(got, "lint"), strings.Index(got, "test"), strings.Index(got, "build")
+	if lint > test || test > build {
+		t.Errorf("Render() should list failed checks first, in order, got:\n%s", got)
+	}
+}
+
+func TestRender_Empty(t *testing.T) {
+	got := ansi.Strip(Render("owner/repo #1 Empty", &gh.Detail{}, 80, ""))
+
+	if !strings.Contains(got, "No description provided.") {
+		t.Errorf("Render() = %q, want the empty description placeholder", got)
+	}
+	for _, heading := range []string{"Labels:", "Checks", "Latest comments"} {
+		if strings.Contains(got, heading) {
+			t.Errorf("Render() should omit %q when empty", heading)
+		}
+	}
+}
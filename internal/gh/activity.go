@@ -3,9 +3,9 @@ package gh
 import "time"
 
 type LatestActivity struct {
-	Kind  string
-	Login string
-	At    string
+	Kind  string `json:"kind"`
+	Login string `json:"login"`
+	At    string `json:"at"`
 }
 
 func NewLatestActivity(commentLogin, commentAt, reviewLogin, reviewAt, reviewState, pushLogin, pushAt string) LatestActivity {
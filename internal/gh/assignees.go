@@ -0,0 +1,126 @@
+package gh
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+const assignableUsersQuery = `query($owner: String!, $name: String!, $after: String) {
+	repository(owner: $owner, name: $name) {
+		assignableUsers(first: 100, after: $after) {
+			nodes { login }
+			pageInfo { hasNextPage endCursor }
+		}
+	}
+}`
+
+const assigneesQuery = `query($id: ID!) {
+	node(id: $id) {
+		... on Assignable {
+			assignees(first: 100) { nodes { login } }
+		}
+	}
+}`
+
+const addAssigneesMutation = `mutation($id: ID!, $users: [ID!]!) {
+	addAssigneesToAssignable(input: {assignableId: $id, assigneeIds: $users}) {
+		clientMutationId
+	}
+}`
+
+const removeAssigneesMutation = `mutation($id: ID!, $users: [ID!]!) {
+	removeAssigneesFromAssignable(input: {assignableId: $id, assigneeIds: $users}) {
+		clientMutationId
+	}
+}`
+
+type loginNodes struct {
+	Nodes []struct {
+		Login string `json:"login"`
+	} `json:"nodes"`
+	PageInfo pageInfo `json:"pageInfo"`
+}
+
+func (n loginNodes) logins() []string {
+	logins := make([]string, 0, len(n.Nodes))
+	for _, u := range n.Nodes {
+		logins = append(logins, u.Login)
+	}
+	return logins
+}
+
+// GetAssignableUsers returns the logins of all the users issues and pull
+// requests of the repository nameWithOwner, e.g. "owner/repo", can be assigned
+// to, following pagination.
+func GetAssignableUsers(client *api.GraphQLClient, nameWithOwner string) ([]string, error) {
+	owner, name, ok := strings.Cut(nameWithOwner, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid repository %q", nameWithOwner)
+	}
+	var users []string
+	vars := map[string]interface{}{"owner": owner, "name": name}
+	for {
+		var resp struct {
+			Repository *struct {
+				AssignableUsers loginNodes `json:"assignableUsers"`
+			} `json:"repository"`
+		}
+		if err := doWithRetry(client, assignableUsersQuery, vars, &resp); err != nil {
+			return nil, err
+		}
+		if resp.Repository == nil {
+			return nil, fmt.Errorf("repository %s not found", nameWithOwner)
+		}
+		users = append(users, resp.Repository.AssignableUsers.logins()...)
+		if !resp.Repository.AssignableUsers.PageInfo.HasNextPage {
+			return users, nil
+		}
+		vars["after"] = resp.Repository.AssignableUsers.PageInfo.EndCursor
+	}
+}
+
+// GetAssignees returns the logins of the users assigned to the issue or pull
+// request with node ID id.
+func GetAssignees(client *api.GraphQLClient, id string) ([]string, error) {
+	var resp struct {
+		Node *struct {
+			Assignees *loginNodes `json:"assignees"`
+		} `json:"node"`
+	}
+	if err := doWithRetry(client, assigneesQuery, map[string]interface{}{"id": id}, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Node == nil || resp.Node.Assignees == nil {
+		return nil, errors.New("not found")
+	}
+	return resp.Node.Assignees.logins(), nil
+}
+
+// ChangeAssignees assigns the users with logins add to, and unassigns those
+// with logins remove from, the issue or pull request with node ID id.
+func ChangeAssignees(client *api.GraphQLClient, id string, add, remove []string) error {
+	for _, change := range []struct {
+		mutation string
+		logins   []string
+	}{{addAssigneesMutation, add}, {removeAssigneesMutation, remove}} {
+		if len(change.logins) == 0 {
+			continue
+		}
+		ids := make([]string, 0, len(change.logins))
+		for _, login := range change.logins {
+			uid, err := userID(client, login)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, uid)
+		}
+		var resp struct{}
+		if err := doWithRetry(client, change.mutation, map[string]interface{}{"id": id, "users": ids}, &resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package gh
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetAssignableUsers(t *testing.T) {
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			body := decodeGraphQLRequest(t, req)
+			if body.Variables["owner"] != "owner" || body.Variables["name"] != "repo" {
+				t.Errorf("variables = %v, want owner and repo", body.Variables)
+			}
+			return jsonResponse(`{"data":{"repository":{"assignableUsers":{"nodes":[{"login":"alice"},{"login":"bob"}]}}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	got, err := GetAssignableUsers(client, "owner/repo")
+	if err != nil {
+		t.Fatalf("GetAssignableUsers() error: %v", err)
+	}
+	if want := []string{"alice", "bob"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetAssignableUsers() = %v, want %v", got, want)
+	}
+}
+
+func TestGetAssignableUsers_FollowsPagination(t *testing.T) {
+	var afters []interface{}
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			body := decodeGraphQLRequest(t, req)
+			afters = append(afters, body.Variables["after"])
+			if body.Variables["after"] == nil {
+				return jsonResponse(`{"data":{"repository":{"assignableUsers":{"nodes":[{"login":"alice"}],"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}}}`), nil
+			}
+			return jsonResponse(`{"data":{"repository":{"assignableUsers":{"nodes":[{"login":"bob"}],"pageInfo":{"hasNextPage":false,"endCursor":"c2"}}}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	got, err := GetAssignableUsers(client, "owner/repo")
+	if err != nil {
+		t.Fatalf("GetAssignableUsers() error: %v", err)
+	}
+	if want := []string{"alice", "bob"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetAssignableUsers() = %v, want %v", got, want)
+	}
+	if len(afters) != 2 || afters[1] != "c1" {
+		t.Errorf("after cursors = %v, want [<nil> c1]", afters)
+	}
+}
+
+func TestGetAssignees_NotFound(t *testing.T) {
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(`{"data":{"node":null}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	if _, err := GetAssignees(client, "I_1"); err == nil {
+		t.Error("GetAssignees() of a missing node should return an error")
+	}
+}
+
+func TestChangeAssignees(t *testing.T) {
+	var requests []graphQLRequest
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, decodeGraphQLRequest(t, req))
+			return jsonResponse(`{"data":{"user":{"id":"U_1"}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	if err := ChangeAssignees(client, "I_1", []string{"alice"}, []string{"bob"}); err != nil {
+		t.Fatalf("ChangeAssignees() error: %v", err)
+	}
+	if len(requests) != 4 {
+		t.Fatalf("made %d requests, want a lookup and a mutation per change", len(requests))
+	}
+	if !strings.Contains(requests[1].Query, "addAssigneesToAssignable") || !strings.Contains(requests[3].Query, "removeAssigneesFromAssignable") {
+		t.Errorf("mutations = %q, %q, want assignees added then removed", requests[1].Query, requests[3].Query)
+	}
+	if users, ok := requests[1].Variables["users"].([]any); !ok || len(users) != 1 || users[0] != "U_1" {
+		t.Errorf("users = %v, want [U_1]", requests[1].Variables["users"])
+	}
+}
@@ -0,0 +1,63 @@
+package gh
+
+import (
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+const latestCommentQuery = `query($id: ID!) {
+	node(id: $id) {
+		... on Issue { comments(last: 1) { nodes { author { login } createdAt body } } }
+		... on PullRequest { comments(last: 1) { nodes { author { login } createdAt body } } }
+	}
+}`
+
+const addCommentMutation = `mutation($id: ID!, $body: String!) {
+	addComment(input: {subjectId: $id, body: $body}) {
+		clientMutationId
+	}
+}`
+
+// GetLatestComment returns the latest comment on the issue or pull request
+// with node ID id, or nil if there is none.
+func GetLatestComment(client *api.GraphQLClient, id string) (*Comment, error) {
+	var resp struct {
+		Node *struct {
+			Comments struct {
+				Nodes []struct {
+					Author struct {
+						Login string `json:"login"`
+					} `json:"author"`
+					CreatedAt string `json:"createdAt"`
+					Body      string `json:"body"`
+				} `json:"nodes"`
+			} `json:"comments"`
+		} `json:"node"`
+	}
+	if err := doWithRetry(client, latestCommentQuery, map[string]interface{}{"id": id}, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Node == nil || len(resp.Node.Comments.Nodes) == 0 {
+		return nil, nil
+	}
+	c := resp.Node.Comments.Nodes[0]
+	return &Comment{Author: c.Author.Login, CreatedAt: c.CreatedAt, Body: c.Body}, nil
+}
+
+// AddComment posts a comment with body on the issue or pull request with
+// node ID id.
+func AddComment(client *api.GraphQLClient, id, body string) error {
+	var resp struct{}
+	return doWithRetry(client, addCommentMutation, map[string]interface{}{"id": id, "body": body}, &resp)
+}
+
+// Quote returns the comment as a Markdown quote to reply below, like the
+// quote reply of GitHub.
+func (c Comment) Quote() string {
+	lines := strings.Split(strings.TrimSpace(c.Body), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight("> "+l, " ")
+	}
+	return strings.Join(lines, "\n") + "\n\n"
+}
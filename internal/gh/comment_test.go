@@ -0,0 +1,66 @@
+package gh
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetLatestComment(t *testing.T) {
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(`{"data":{"node":{"comments":{"nodes":[
+				{"author":{"login":"alice"},"createdAt":"2024-01-02T00:00:00Z","body":"@bob can you take a look?"}
+			]}}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	got, err := GetLatestComment(client, "I_1")
+	if err != nil {
+		t.Fatalf("GetLatestComment() error: %v", err)
+	}
+	if got == nil || got.Author != "alice" || got.Body != "@bob can you take a look?" {
+		t.Errorf("GetLatestComment() = %+v, want the comment of alice", got)
+	}
+}
+
+func TestGetLatestComment_None(t *testing.T) {
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(`{"data":{"node":{"comments":{"nodes":[]}}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	got, err := GetLatestComment(client, "I_1")
+	if err != nil || got != nil {
+		t.Errorf("GetLatestComment() = %+v, %v, want nil without comments", got, err)
+	}
+}
+
+func TestAddComment(t *testing.T) {
+	var got graphQLRequest
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			got = decodeGraphQLRequest(t, req)
+			return jsonResponse(`{"data":{}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	if err := AddComment(client, "I_1", "LGTM"); err != nil {
+		t.Fatalf("AddComment() error: %v", err)
+	}
+	if !strings.Contains(got.Query, "addComment") || got.Variables["id"] != "I_1" || got.Variables["body"] != "LGTM" {
+		t.Errorf("request = %+v, want addComment with id I_1 and body LGTM", got)
+	}
+}
+
+func TestComment_Quote(t *testing.T) {
+	c := Comment{Body: "first line\n\nsecond line\n"}
+	want := "> first line\n>\n> second line\n\n"
+	if got := c.Quote(); got != want {
+		t.Errorf("Quote() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,271 @@
+package gh
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// detailComments is the number of most recent comments fetched for a detail view.
+const detailComments = 3
+
+// Detail holds what is shown in the detail view of a pull request or issue.
+type Detail struct {
+	Body      string
+	Labels    []string
+	Assignees []string
+	// ReviewRequests lists the users and teams asked to review a pull request.
+	ReviewRequests []string
+	// Checks lists the CI checks of the head commit of a pull request.
+	Checks   []Check
+	Comments []Comment
+}
+
+// Check is a single CI check run or commit status.
+type Check struct {
+	Name string
+	// State is SUCCESS, FAILURE, ERROR, PENDING, NEUTRAL, SKIPPED or CANCELLED.
+	State string
+	// DetailsURL links to the job log of a check run or the target of a
+	// commit status.
+	DetailsURL  string
+	StartedAt   string
+	CompletedAt string
+}
+
+// Failed reports whether the check failed.
+func (c Check) Failed() bool {
+	return c.State == "FAILURE" || c.State == "ERROR"
+}
+
+// Duration returns how long a completed check run took, or zero if unknown.
+func (c Check) Duration() time.Duration {
+	started, err := time.Parse(time.RFC3339, c.StartedAt)
+	if err != nil {
+		return 0
+	}
+	completed, err := time.Parse(time.RFC3339, c.CompletedAt)
+	if err != nil || completed.Before(started) {
+		return 0
+	}
+	return completed.Sub(started)
+}
+
+// Comment is a comment on a pull request or issue.
+type Comment struct {
+	Author    string
+	CreatedAt string
+	Body      string
+}
+
+// checksFragment selects the CI checks of the head commit of a pull request.
+const checksFragment = `commits(last: 1) {
+	nodes {
+		commit {
+			statusCheckRollup {
+				contexts(first: 100) {
+					nodes {
+						... on CheckRun { name status conclusion detailsUrl startedAt completedAt }
+						... on StatusContext { context state targetUrl }
+					}
+				}
+			}
+		}
+	}
+}`
+
+const prDetailQuery = `query($id: ID!, $comments: Int!) {
+	node(id: $id) {
+		... on PullRequest {
+			body
+			labels(first: 20) { nodes { name } }
+			assignees(first: 20) { nodes { login } }
+			reviewRequests(first: 20) {
+				nodes {
+					requestedReviewer {
+						... on User { login }
+						... on Team { combinedSlug }
+					}
+				}
+			}
+			comments(last: $comments) { nodes { author { login } createdAt body } }
+			` + checksFragment + `
+		}
+	}
+}`
+
+const prChecksQuery = `query($id: ID!) {
+	node(id: $id) {
+		... on PullRequest {
+			` + checksFragment + `
+		}
+	}
+}`
+
+const issueDetailQuery = `query($id: ID!, $comments: Int!) {
+	node(id: $id) {
+		... on Issue {
+			body
+			labels(first: 20) { nodes { name } }
+			assignees(first: 20) { nodes { login } }
+			comments(last: $comments) { nodes { author { login } createdAt body } }
+		}
+	}
+}`
+
+type detailRawNode struct {
+	Body   *string `json:"body"`
+	Labels struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	Assignees struct {
+		Nodes []struct {
+			Login string `json:"login"`
+		} `json:"nodes"`
+	} `json:"assignees"`
+	ReviewRequests struct {
+		Nodes []struct {
+			RequestedReviewer struct {
+				Login        string `json:"login"`
+				CombinedSlug string `json:"combinedSlug"`
+			} `json:"requestedReviewer"`
+		} `json:"nodes"`
+	} `json:"reviewRequests"`
+	Comments struct {
+		Nodes []struct {
+			Author struct {
+				Login string `json:"login"`
+			} `json:"author"`
+			CreatedAt string `json:"createdAt"`
+			Body      string `json:"body"`
+		} `json:"nodes"`
+	} `json:"comments"`
+	Commits commitsRaw `json:"commits"`
+}
+
+type commitsRaw struct {
+	Nodes []struct {
+		Commit struct {
+			StatusCheckRollup *struct {
+				Contexts struct {
+					Nodes []checkRawNode `json:"nodes"`
+				} `json:"contexts"`
+			} `json:"statusCheckRollup"`
+		} `json:"commit"`
+	} `json:"nodes"`
+}
+
+type checkRawNode struct {
+	// CheckRun fields.
+	Name        string `json:"name"`
+	Status      string `json:"status"`
+	Conclusion  string `json:"conclusion"`
+	DetailsURL  string `json:"detailsUrl"`
+	StartedAt   string `json:"startedAt"`
+	CompletedAt string `json:"completedAt"`
+	// StatusContext fields.
+	Context   string `json:"context"`
+	State     string `json:"state"`
+	TargetURL string `json:"targetUrl"`
+}
+
+// GetPRDetail fetches the detail view of the pull request with node ID id.
+func GetPRDetail(client *api.GraphQLClient, id string) (*Detail, error) {
+	return getDetail(client, prDetailQuery, id)
+}
+
+// GetIssueDetail fetches the detail view of the issue with node ID id.
+func GetIssueDetail(client *api.GraphQLClient, id string) (*Detail, error) {
+	return getDetail(client, issueDetailQuery, id)
+}
+
+// GetPRChecks fetches the CI checks of the head commit of the pull request
+// with node ID id.
+func GetPRChecks(client *api.GraphQLClient, id string) ([]Check, error) {
+	var resp struct {
+		Node *struct {
+			Commits *commitsRaw `json:"commits"`
+		} `json:"node"`
+	}
+	if err := doWithRetry(client, prChecksQuery, map[string]interface{}{"id": id}, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Node == nil || resp.Node.Commits == nil {
+		return nil, errors.New("not found")
+	}
+	return resp.Node.Commits.checks(), nil
+}
+
+func getDetail(client *api.GraphQLClient, query, id string) (*Detail, error) {
+	var resp struct {
+		Node *detailRawNode `json:"node"`
+	}
+	vars := map[string]interface{}{"id": id, "comments": detailComments}
+	if err := doWithRetry(client, query, vars, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Node == nil || resp.Node.Body == nil {
+		return nil, errors.New("not found")
+	}
+	return parseDetail(resp.Node), nil
+}
+
+func parseDetail(n *detailRawNode) *Detail {
+	d := &Detail{Body: *n.Body}
+	for _, l := range n.Labels.Nodes {
+		d.Labels = append(d.Labels, l.Name)
+	}
+	for _, a := range n.Assignees.Nodes {
+		d.Assignees = append(d.Assignees, a.Login)
+	}
+	for _, r := range n.ReviewRequests.Nodes {
+		if r.RequestedReviewer.Login != "" {
+			d.ReviewRequests = append(d.ReviewRequests, r.RequestedReviewer.Login)
+		} else if r.RequestedReviewer.CombinedSlug != "" {
+			d.ReviewRequests = append(d.ReviewRequests, r.RequestedReviewer.CombinedSlug)
+		}
+	}
+	for _, c := range n.Comments.Nodes {
+		d.Comments = append(d.Comments, Comment{Author: c.Author.Login, CreatedAt: c.CreatedAt, Body: c.Body})
+	}
+	d.Checks = n.Commits.checks()
+	return d
+}
+
+// checks returns the CI checks of the head commit.
+func (c *commitsRaw) checks() []Check {
+	if len(c.Nodes) == 0 || c.Nodes[0].Commit.StatusCheckRollup == nil {
+		return nil
+	}
+	var checks []Check
+	for _, n := range c.Nodes[0].Commit.StatusCheckRollup.Contexts.Nodes {
+		checks = append(checks, parseCheck(n))
+	}
+	return checks
+}
+
+// parseCheck normalizes a check run or commit status into a Check.
+func parseCheck(c checkRawNode) Check {
+	if c.Context != "" {
+		return Check{Name: c.Context, State: c.State, DetailsURL: c.TargetURL}
+	}
+	state := c.Conclusion
+	switch {
+	case c.Status != "COMPLETED":
+		state = "PENDING"
+	case state == "TIMED_OUT" || state == "STARTUP_FAILURE" || state == "ACTION_REQUIRED":
+		state = "FAILURE"
+	case state == "STALE":
+		state = "NEUTRAL"
+	}
+	return Check{
+		Name:        c.Name,
+		State:       state,
+		DetailsURL:  c.DetailsURL,
+		StartedAt:   c.StartedAt,
+		CompletedAt: c.CompletedAt,
+	}
+}
@@ -0,0 +1,136 @@
+package gh
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetPRDetail(t *testing.T) {
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			body := decodeGraphQLRequest(t, req)
+			if body.Variables["id"] != "PR_1" {
+				t.Errorf("id = %v, want PR_1", body.Variables["id"])
+			}
+			return jsonResponse(`{"data":{"node":{
+				"body":"Fixes the bug.",
+				"labels":{"nodes":[{"name":"bug"}]},
+				"assignees":{"nodes":[{"login":"alice"}]},
+				"reviewRequests":{"nodes":[{"requestedReviewer":{"login":"bob"}},{"requestedReviewer":{"combinedSlug":"acme/core"}}]},
+				"comments":{"nodes":[{"author":{"login":"carol"},"createdAt":"2024-03-15T10:00:00Z","body":"LGTM"}]},
+				"commits":{"nodes":[{"commit":{"statusCheckRollup":{"contexts":{"nodes":[
+					{"name":"build","status":"COMPLETED","conclusion":"SUCCESS","detailsUrl":"https://github.com/o/r/actions/runs/1/job/2","startedAt":"2024-03-15T10:00:00Z","completedAt":"2024-03-15T10:02:30Z"},
+					{"name":"test","status":"IN_PROGRESS","conclusion":null},
+					{"context":"ci/legacy","state":"FAILURE","targetUrl":"https://ci.example.com/42"}
+				]}}}}]}
+			}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	got, err := GetPRDetail(client, "PR_1")
+	if err != nil {
+		t.Fatalf("GetPRDetail() error: %v", err)
+	}
+
+	want := &Detail{
+		Body:           "Fixes the bug.",
+		Labels:         []string{"bug"},
+		Assignees:      []string{"alice"},
+		ReviewRequests: []string{"bob", "acme/core"},
+		Checks: []Check{
+			{
+				Name:        "build",
+				State:       "SUCCESS",
+				DetailsURL:  "https://github.com/o/r/actions/runs/1/job/2",
+				StartedAt:   "2024-03-15T10:00:00Z",
+				CompletedAt: "2024-03-15T10:02:30Z",
+			},
+			{Name: "test", State: "PENDING"},
+			{Name: "ci/legacy", State: "FAILURE", DetailsURL: "https://ci.example.com/42"},
+		},
+		Comments: []Comment{{Author: "carol", CreatedAt: "2024-03-15T10:00:00Z", Body: "LGTM"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetPRDetail() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGetIssueDetail_NotFound(t *testing.T) {
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(`{"data":{"node":null}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	if _, err := GetIssueDetail(client, "I_1"); err == nil {
+		t.Error("GetIssueDetail() of a missing node should return an error")
+	}
+}
+
+func TestParseCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  checkRawNode
+		want string
+	}{
+		{"completed check run", checkRawNode{Name: "a", Status: "COMPLETED", Conclusion: "SKIPPED"}, "SKIPPED"},
+		{"queued check run", checkRawNode{Name: "a", Status: "QUEUED"}, "PENDING"},
+		{"timed out check run", checkRawNode{Name: "a", Status: "COMPLETED", Conclusion: "TIMED_OUT"}, "FAILURE"},
+		{"status context", checkRawNode{Context: "a", State: "ERROR"}, "ERROR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseCheck(tt.raw); got.State != tt.want || got.Name != "a" {
+				t.Errorf("parseCheck() = %+v, want name a and state %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPRChecks(t *testing.T) {
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			body := decodeGraphQLRequest(t, req)
+			if strings.Contains(body.Query, "comments") {
+				t.Error("GetPRChecks() should only query the checks")
+			}
+			return jsonResponse(`{"data":{"node":{"commits":{"nodes":[{"commit":{"statusCheckRollup":{"contexts":{"nodes":[
+				{"name":"lint","status":"COMPLETED","conclusion":"FAILURE","detailsUrl":"https://github.com/o/r/actions/runs/1/job/3"}
+			]}}}}]}}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	got, err := GetPRChecks(client, "PR_1")
+	if err != nil {
+		t.Fatalf("GetPRChecks() error: %v", err)
+	}
+	want := []Check{{Name: "lint", State: "FAILURE", DetailsURL: "https://github.com/o/r/actions/runs/1/job/3"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetPRChecks() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCheck_Duration(t *testing.T) {
+	tests := []struct {
+		name  string
+		check Check
+		want  time.Duration
+	}{
+		{"completed", Check{StartedAt: "2024-03-15T10:00:00Z", CompletedAt: "2024-03-15T10:01:05Z"}, 65 * time.Second},
+		{"still running", Check{StartedAt: "2024-03-15T10:00:00Z"}, 0},
+		{"status context", Check{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.check.Duration(); got != tt.want {
+				t.Errorf("Duration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
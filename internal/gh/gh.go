@@ -3,7 +3,13 @@ package gh
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/cli/go-gh/v2/pkg/api"
@@ -16,15 +22,79 @@ type User struct {
 	Login string `json:"login"`
 }
 
+// DefaultHostname is the hostname of github.com.
+const DefaultHostname = "github.com"
+
+// ClientFunc returns the GraphQL client for host.
+type ClientFunc func(host string) (*api.GraphQLClient, error)
+
+// TeamsFunc returns the slugs of the teams the current user is a member of on
+// host, e.g. "acme/core".
+type TeamsFunc func(host string) ([]string, error)
+
 func CurrentLogin() (string, error) {
+	host, _ := auth.DefaultHost()
+	return CurrentLoginForHost(host)
+}
+
+// CurrentLoginForHost returns the login gh is authenticated as on host.
+func CurrentLoginForHost(host string) (string, error) {
 	cfg, err := config.Read(nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to read gh config: %w", err)
 	}
-	host, _ := auth.DefaultHost()
 	return loginFromConfig(cfg, host)
 }
 
+// HostFromURL returns the hostname of a GitHub web URL, defaulting to github.com.
+func HostFromURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return DefaultHostname
+	}
+	return strings.ToLower(u.Host)
+}
+
+// repositoryAPIURL returns the REST API URL of a repository on host.
+func repositoryAPIURL(host, nameWithOwner string) string {
+	switch {
+	case host == DefaultHostname:
+		return fmt.Sprintf("https://api.github.com/repos/%s", nameWithOwner)
+	case strings.HasSuffix(host, ".ghe.com"):
+		return fmt.Sprintf("https://api.%s/repos/%s", host, nameWithOwner)
+	default:
+		return fmt.Sprintf("https://%s/api/v3/repos/%s", host, nameWithOwner)
+	}
+}
+
+// Editor returns the editor gh is set up to use, from GH_EDITOR, the editor
+// in the gh config, GIT_EDITOR, VISUAL or EDITOR, in that order. It is empty
+// if none is set.
+func Editor() string {
+	if editor := os.Getenv("GH_EDITOR"); editor != "" {
+		return editor
+	}
+	if cfg, err := config.Read(nil); err == nil {
+		if editor := editorFromConfig(cfg); editor != "" {
+			return editor
+		}
+	}
+	for _, env := range []string{"GIT_EDITOR", "VISUAL", "EDITOR"} {
+		if editor := os.Getenv(env); editor != "" {
+			return editor
+		}
+	}
+	return ""
+}
+
+func editorFromConfig(cfg *config.Config) string {
+	editor, err := cfg.Get([]string{"editor"})
+	if err != nil {
+		return ""
+	}
+	return editor
+}
+
 func loginFromConfig(cfg *config.Config, host string) (string, error) {
 	login, err := cfg.Get([]string{"hosts", host, "user"})
 	if err != nil {
@@ -77,48 +147,244 @@ func parseTeamSlugs(teams []teamResponse) []string {
 	return slugs
 }
 
+// DefaultSearchLimit is the maximum number of results fetched per query when no limit is configured.
+const DefaultSearchLimit = 50
+
+// maxPageSize is the largest page the GitHub search API returns.
+const maxPageSize = 100
+
+// SearchPage holds the nodes fetched for one search query together with the
+// total number of matches GitHub reported for it.
+type SearchPage[T any] struct {
+	Nodes      []T
+	IssueCount int
+	// RateLimit is the rate limit after the last page, with the cost of all pages.
+	RateLimit RateLimit
+}
+
+// remaining returns how many matches were not fetched because of the limit.
+func (p SearchPage[T]) remaining() int {
+	return max(0, p.IssueCount-len(p.Nodes))
+}
+
+// pageInfo tells whether a GraphQL connection has more nodes after EndCursor.
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+type searchInfo struct {
+	IssueCount int      `json:"issueCount"`
+	PageInfo   pageInfo `json:"pageInfo"`
+}
+
 func searchOne[T any](
 	client *api.GraphQLClient,
 	gql string,
 	search string,
+	limit int,
 	parse func(json.RawMessage) ([]T, error),
-) ([]T, error) {
-	vars := map[string]interface{}{"q": search}
+) (SearchPage[T], error) {
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
 
-	var raw map[string]json.RawMessage
-	if err := client.Do(gql, vars, &raw); err != nil {
-		return nil, err
+	var page SearchPage[T]
+	var cursor string
+	for {
+		vars := map[string]interface{}{
+			"q":     search,
+			"first": min(maxPageSize, limit-len(page.Nodes)),
+		}
+		if cursor != "" {
+			vars["after"] = cursor
+		}
+
+		var raw map[string]json.RawMessage
+		if err := doWithRetry(client, gql, vars, &raw); err != nil {
+			return SearchPage[T]{}, err
+		}
+
+		if data, ok := raw["rateLimit"]; ok {
+			var rl RateLimit
+			if err := json.Unmarshal(data, &rl); err == nil {
+				page.RateLimit = mergeRateLimit(rl, RateLimit{Cost: page.RateLimit.Cost})
+			}
+		}
+
+		var info searchInfo
+		if err := json.Unmarshal(raw["result"], &info); err != nil {
+			return SearchPage[T]{}, err
+		}
+		nodes, err := parse(raw["result"])
+		if err != nil {
+			return SearchPage[T]{}, err
+		}
+
+		page.Nodes = append(page.Nodes, nodes...)
+		page.IssueCount = info.IssueCount
+
+		if !info.PageInfo.HasNextPage || len(page.Nodes) >= limit {
+			return page, nil
+		}
+		cursor = info.PageInfo.EndCursor
 	}
-	return parse(raw["result"])
 }
 
+// Search runs the searches in entries concurrently, at most
+// maxConcurrentSearches at a time per client, also counting the searches of
+// other Search calls on client, following pagination until limit nodes are
+// fetched per entry. If some searches fail, the pages of the others are
+// returned together with a *SearchError.
 func Search[T any](
 	client *api.GraphQLClient,
 	gql string,
 	entries map[string]string,
+	limit int,
 	parse func(json.RawMessage) ([]T, error),
-) (map[string][]T, error) {
+) (map[string]SearchPage[T], error) {
 	type result struct {
-		key   string
-		nodes []T
-		err   error
+		key  string
+		page SearchPage[T]
+		err  error
 	}
 
 	ch := make(chan result, len(entries))
+	sem := acquireSearchLimiter(client)
+	defer releaseSearchLimiter(client)
 	for key, search := range entries {
 		go func(key, search string) {
-			nodes, err := searchOne(client, gql, search, parse)
-			ch <- result{key: key, nodes: nodes, err: err}
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			page, err := searchOne(client, gql, search, limit, parse)
+			ch <- result{key: key, page: page, err: err}
 		}(key, search)
 	}
 
-	merged := make(map[string][]T, len(entries))
+	merged := make(map[string]SearchPage[T], len(entries))
+	errs := make(map[string]error)
 	for range entries {
 		r := <-ch
 		if r.err != nil {
-			return nil, r.err
+			errs[r.key] = r.err
+			continue
 		}
-		merged[r.key] = r.nodes
+		merged[r.key] = r.page
+	}
+	if len(errs) > 0 {
+		return merged, &SearchError{Errs: errs}
 	}
 	return merged, nil
 }
+
+// SearchError reports the searches that failed, keyed by entry key.
+type SearchError struct {
+	Errs map[string]error
+}
+
+func (e *SearchError) Error() string {
+	keys := make([]string, 0, len(e.Errs))
+	for k := range e.Errs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	msgs := make([]string, len(keys))
+	for i, k := range keys {
+		msgs[i] = fmt.Sprintf("%s: %v", k, e.Errs[k])
+	}
+	return "search failed: " + strings.Join(msgs, "; ")
+}
+
+// tabErrors returns the errors keyed by tab, folding every participated*
+// search into "participated". It is safe to call on a nil *SearchError.
+func (e *SearchError) tabErrors() map[string]error {
+	if e == nil {
+		return nil
+	}
+	tabs := make(map[string]error, len(e.Errs))
+	for k, err := range e.Errs {
+		if strings.HasPrefix(k, "participated") {
+			k = "participated"
+		}
+		if _, ok := tabs[k]; !ok {
+			tabs[k] = err
+		}
+	}
+	return tabs
+}
+
+// splitSearchError separates per-search failures from other errors. It
+// returns the *SearchError, if any, or err if it is of another kind.
+func splitSearchError(err error) (*SearchError, error) {
+	if err == nil {
+		return nil, nil
+	}
+	var searchErr *SearchError
+	if errors.As(err, &searchErr) {
+		return searchErr, nil
+	}
+	return nil, err
+}
+
+// maxErrorSummary is the longest error summary shown in a tab title.
+const maxErrorSummary = 40
+
+// ErrorSummary returns a short description of err suitable for a tab title.
+func ErrorSummary(err error) string {
+	if isRateLimited(err) {
+		return "rate limited"
+	}
+
+	msg := err.Error()
+	var gqlErr *api.GraphQLError
+	var httpErr *api.HTTPError
+	switch {
+	case errors.As(err, &gqlErr) && len(gqlErr.Errors) > 0:
+		msg = gqlErr.Errors[0].Message
+	case errors.As(err, &httpErr):
+		msg = fmt.Sprintf("HTTP %d", httpErr.StatusCode)
+	}
+
+	msg = strings.TrimSpace(strings.SplitN(msg, "\n", 2)[0])
+	if r := []rune(msg); len(r) > maxErrorSummary {
+		msg = string(r[:maxErrorSummary-1]) + "…"
+	}
+	return msg
+}
+
+// mergeErrors combines the tab errors of two results, keeping the first error per tab.
+func mergeErrors(a, b map[string]error) map[string]error {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	merged := make(map[string]error, len(a)+len(b))
+	for k, err := range b {
+		merged[k] = err
+	}
+	for k, err := range a {
+		merged[k] = err
+	}
+	return merged
+}
+
+// keepTotals returns a copy of totals in which the keys in errs have the
+// totals of prev instead.
+func keepTotals(totals, prev map[string]int, errs map[string]error) map[string]int {
+	kept := make(map[string]int, len(totals))
+	maps.Copy(kept, totals)
+	for key := range errs {
+		if total, ok := prev[key]; ok {
+			kept[key] = total
+		} else {
+			delete(kept, key)
+		}
+	}
+	return kept
+}
+
+// mergeTotal returns the total number of matches for a merged, deduplicated
+// list of n nodes built from two searches.
+func mergeTotal(n, aTotal, aLen, bTotal, bLen int) int {
+	return n + max(0, aTotal-aLen) + max(0, bTotal-bLen)
+}
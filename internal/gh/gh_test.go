@@ -2,6 +2,7 @@ package gh
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -37,6 +38,40 @@ func newTestRESTClient(t *testing.T, transport http.RoundTripper) *api.RESTClien
 	return client
 }
 
+func newTestGraphQLClient(t *testing.T, transport http.RoundTripper) *api.GraphQLClient {
+	t.Helper()
+	client, err := api.NewGraphQLClient(api.ClientOptions{
+		AuthToken: "test-token",
+		Transport: transport,
+	})
+	if err != nil {
+		t.Fatalf("failed to create test GraphQL client: %v", err)
+	}
+	return client
+}
+
+func jsonResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+	}
+}
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+func decodeGraphQLRequest(t *testing.T, req *http.Request) graphQLRequest {
+	t.Helper()
+	var body graphQLRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode GraphQL request: %v", err)
+	}
+	return body
+}
+
 func TestParseTeamSlugs(t *testing.T) {
 	teams := []teamResponse{
 		{Slug: "team-a"},
@@ -130,6 +165,50 @@ func TestLoginFromConfig_ReadsUsername(t *testing.T) {
 	}
 }
 
+func TestLoginFromConfig_ReadsUsernameForEnterpriseHost(t *testing.T) {
+	cfg := config.ReadFromString("hosts:\n  github.com:\n    user: publicuser\n  ghe.example.com:\n    user: corpuser\n")
+	login, err := loginFromConfig(cfg, "ghe.example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if login != "corpuser" {
+		t.Errorf("loginFromConfig() = %q, want %q", login, "corpuser")
+	}
+}
+
+func TestHostFromURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://github.com/owner/repo/pull/1", "github.com"},
+		{"https://GHE.example.com/owner/repo/issues/2", "ghe.example.com"},
+		{"", "github.com"},
+		{"not a url", "github.com"},
+	}
+	for _, tt := range tests {
+		if got := HostFromURL(tt.url); got != tt.want {
+			t.Errorf("HostFromURL(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestRepositoryAPIURL(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"github.com", "https://api.github.com/repos/owner/repo"},
+		{"ghe.example.com", "https://ghe.example.com/api/v3/repos/owner/repo"},
+		{"acme.ghe.com", "https://api.acme.ghe.com/repos/owner/repo"},
+	}
+	for _, tt := range tests {
+		if got := repositoryAPIURL(tt.host, "owner/repo"); got != tt.want {
+			t.Errorf("repositoryAPIURL(%q) = %q, want %q", tt.host, got, tt.want)
+		}
+	}
+}
+
 func TestGetTeamSlugsWithCache_CacheHit(t *testing.T) {
 	tmpDir := t.TempDir()
 	store := cache.NewStoreWithPath(filepath.Join(tmpDir, "teams.json"))
@@ -300,3 +379,241 @@ func TestGetTeamSlugsWithCache_CacheExpired(t *testing.T) {
 		t.Errorf("got teams %v, want [new-org/new-team]", teams)
 	}
 }
+
+type testNode struct {
+	Number int `json:"number"`
+}
+
+func parseTestNodes(data json.RawMessage) ([]testNode, error) {
+	var sr struct {
+		Nodes []testNode `json:"nodes"`
+	}
+	if err := json.Unmarshal(data, &sr); err != nil {
+		return nil, err
+	}
+	return sr.Nodes, nil
+}
+
+func TestSearch_FollowsCursorUntilLastPage(t *testing.T) {
+	var afters []interface{}
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			body := decodeGraphQLRequest(t, req)
+			afters = append(afters, body.Variables["after"])
+			if body.Variables["after"] == nil {
+				return jsonResponse(`{"data":{"result":{"issueCount":3,"pageInfo":{"hasNextPage":true,"endCursor":"c1"},"nodes":[{"number":1},{"number":2}]}}}`), nil
+			}
+			return jsonResponse(`{"data":{"result":{"issueCount":3,"pageInfo":{"hasNextPage":false,"endCursor":"c2"},"nodes":[{"number":3}]}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	pages, err := Search(client, "query", map[string]string{"created": "is:pr"}, 10, parseTestNodes)
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+
+	page := pages["created"]
+	if len(page.Nodes) != 3 {
+		t.Errorf("got %d nodes, want 3", len(page.Nodes))
+	}
+	if page.IssueCount != 3 {
+		t.Errorf("IssueCount = %d, want 3", page.IssueCount)
+	}
+	if len(afters) != 2 || afters[1] != "c1" {
+		t.Errorf("after cursors = %v, want [<nil> c1]", afters)
+	}
+}
+
+func TestSearch_StopsAtLimit(t *testing.T) {
+	calls := 0
+	var firsts []interface{}
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			calls++
+			body := decodeGraphQLRequest(t, req)
+			firsts = append(firsts, body.Variables["first"])
+			return jsonResponse(`{"data":{"result":{"issueCount":312,"pageInfo":{"hasNextPage":true,"endCursor":"c"},"nodes":[{"number":1},{"number":2}]}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	pages, err := Search(client, "query", map[string]string{"created": "is:pr"}, 2, parseTestNodes)
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("API was called %d times, want 1", calls)
+	}
+	if len(firsts) != 1 || firsts[0] != float64(2) {
+		t.Errorf("first = %v, want [2]", firsts)
+	}
+	page := pages["created"]
+	if page.IssueCount != 312 {
+		t.Errorf("IssueCount = %d, want 312", page.IssueCount)
+	}
+	if page.remaining() != 310 {
+		t.Errorf("remaining() = %d, want 310", page.remaining())
+	}
+}
+
+func TestSearch_PageSizeCappedAtMax(t *testing.T) {
+	var first interface{}
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			first = decodeGraphQLRequest(t, req).Variables["first"]
+			return jsonResponse(`{"data":{"result":{"issueCount":0,"pageInfo":{"hasNextPage":false},"nodes":[]}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	if _, err := Search(client, "query", map[string]string{"created": "is:pr"}, 500, parseTestNodes); err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+
+	if first != float64(maxPageSize) {
+		t.Errorf("first = %v, want %d", first, maxPageSize)
+	}
+}
+
+func TestSearch_ErrorOnAPIFailure(t *testing.T) {
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			return nil, fmt.Errorf("network error")
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	if _, err := Search(client, "query", map[string]string{"created": "is:pr"}, 10, parseTestNodes); err == nil {
+		t.Fatal("expected error on API failure, got nil")
+	}
+}
+
+func TestSearch_PartialFailure(t *testing.T) {
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			if decodeGraphQLRequest(t, req).Variables["q"] == "bad" {
+				return jsonResponse(`{"errors":[{"type":"INVALID","message":"invalid query"}]}`), nil
+			}
+			return jsonResponse(`{"data":{"result":{"issueCount":1,"pageInfo":{"hasNextPage":false},"nodes":[{"number":1}]}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	pages, err := Search(client, "query", map[string]string{"created": "is:pr", "needs-triage": "bad"}, 10, parseTestNodes)
+
+	var searchErr *SearchError
+	if !errors.As(err, &searchErr) {
+		t.Fatalf("Search() error = %v, want *SearchError", err)
+	}
+	if _, ok := searchErr.Errs["needs-triage"]; !ok || len(searchErr.Errs) != 1 {
+		t.Errorf("SearchError.Errs = %v, want only needs-triage", searchErr.Errs)
+	}
+	if len(pages["created"].Nodes) != 1 {
+		t.Errorf("got %d created nodes, want 1 despite the other search failing", len(pages["created"].Nodes))
+	}
+}
+
+func TestSearchError_TabErrors(t *testing.T) {
+	e := &SearchError{Errs: map[string]error{
+		"participatedTeam3": fmt.Errorf("team failed"),
+		"needs-triage":      fmt.Errorf("bad query"),
+	}}
+
+	tabs := e.tabErrors()
+	if len(tabs) != 2 {
+		t.Fatalf("tabErrors() = %v, want 2 entries", tabs)
+	}
+	if tabs["participated"] == nil || tabs["needs-triage"] == nil {
+		t.Errorf("tabErrors() = %v, want participated and needs-triage", tabs)
+	}
+
+	var nilErr *SearchError
+	if got := nilErr.tabErrors(); got != nil {
+		t.Errorf("nil tabErrors() = %v, want nil", got)
+	}
+}
+
+func TestSplitSearchError(t *testing.T) {
+	searchErr := &SearchError{Errs: map[string]error{"created": fmt.Errorf("x")}}
+	if se, err := splitSearchError(fmt.Errorf("wrapped: %w", searchErr)); se != searchErr || err != nil {
+		t.Errorf("splitSearchError(SearchError) = (%v, %v), want (searchErr, nil)", se, err)
+	}
+
+	other := fmt.Errorf("auth failed")
+	if se, err := splitSearchError(other); se != nil || err != other {
+		t.Errorf("splitSearchError(other) = (%v, %v), want (nil, other)", se, err)
+	}
+
+	if se, err := splitSearchError(nil); se != nil || err != nil {
+		t.Errorf("splitSearchError(nil) = (%v, %v), want (nil, nil)", se, err)
+	}
+}
+
+func TestErrorSummary(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"GraphQL error uses first message", &api.GraphQLError{Errors: []api.GraphQLErrorItem{{Message: "invalid query"}}}, "invalid query"},
+		{"HTTP error uses status", &api.HTTPError{StatusCode: 502, Message: "Bad Gateway"}, "HTTP 502"},
+		{"rate limit", &api.HTTPError{StatusCode: 429}, "rate limited"},
+		{"first line only", fmt.Errorf("first\nsecond"), "first"},
+		{"truncated", fmt.Errorf("%s", strings.Repeat("x", 60)), strings.Repeat("x", 39) + "…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorSummary(tt.err); got != tt.want {
+				t.Errorf("ErrorSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeErrors(t *testing.T) {
+	errA := fmt.Errorf("a")
+	errB := fmt.Errorf("b")
+
+	merged := mergeErrors(map[string]error{"created": errA}, map[string]error{"created": errB, "participated": errB})
+	if merged["created"] != errA {
+		t.Errorf("merged[created] = %v, want first result's error", merged["created"])
+	}
+	if merged["participated"] != errB {
+		t.Errorf("merged[participated] = %v, want %v", merged["participated"], errB)
+	}
+
+	if got := mergeErrors(nil, nil); got != nil {
+		t.Errorf("mergeErrors(nil, nil) = %v, want nil", got)
+	}
+}
+
+func TestMergeTotal(t *testing.T) {
+	// 3 merged nodes; a fetched 2 of 10, b fetched all 2 of 2.
+	if got := mergeTotal(3, 10, 2, 2, 2); got != 11 {
+		t.Errorf("mergeTotal() = %d, want 11", got)
+	}
+	// Unknown totals (0) never reduce the count below the merged length.
+	if got := mergeTotal(3, 0, 2, 0, 2); got != 3 {
+		t.Errorf("mergeTotal() = %d, want 3", got)
+	}
+}
+
+func TestEditorFromConfig(t *testing.T) {
+	cfg := config.ReadFromString("editor: nvim\n")
+	if got := editorFromConfig(cfg); got != "nvim" {
+		t.Errorf("editorFromConfig() = %q, want %q", got, "nvim")
+	}
+	if got := editorFromConfig(config.ReadFromString("")); got != "" {
+		t.Errorf("editorFromConfig() without an editor = %q, want empty", got)
+	}
+}
+
+func TestEditor_PrefersGHEditor(t *testing.T) {
+	t.Setenv("GH_EDITOR", "code --wait")
+	t.Setenv("EDITOR", "vi")
+	if got := Editor(); got != "code --wait" {
+		t.Errorf("Editor() = %q, want %q", got, "code --wait")
+	}
+}
@@ -3,26 +3,33 @@ package gh
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"strings"
 
 	"github.com/cli/go-gh/v2/pkg/api"
 	"github.com/snrsw/gh-own/internal/config"
 )
 
-func SearchIssues(client *api.GraphQLClient, entries map[string]string) (*IssueSearchResult, error) {
+func SearchIssues(client *api.GraphQLClient, entries map[string]string, limit int) (*IssueSearchResult, error) {
 	if len(entries) == 0 {
 		return &IssueSearchResult{Custom: make(map[string][]IssueSearchNode)}, nil
 	}
 
-	raw, err := Search(client, issueSearchQuery, entries, parseIssueSearchJSON)
+	raw, err := Search(client, issueSearchQuery, entries, limit, parseIssueSearchJSON)
+	searchErr, err := splitSearchError(err)
 	if err != nil {
 		return nil, err
 	}
 
-	return parseIssueSearchResult(raw)
+	result, err := parseIssueSearchResult(raw)
+	if err != nil {
+		return nil, err
+	}
+	result.Errors = searchErr.tabErrors()
+	return result, nil
 }
 
-func SearchIssuesTeams(client *api.GraphQLClient, username string, teams []string) (*IssueSearchResult, error) {
+func SearchIssuesTeams(client *api.GraphQLClient, username string, teams []string, limit int) (*IssueSearchResult, error) {
 	if username == "" {
 		return &IssueSearchResult{Custom: make(map[string][]IssueSearchNode)}, nil
 	}
@@ -36,12 +43,18 @@ func SearchIssuesTeams(client *api.GraphQLClient, username string, teams []strin
 		entries[fmt.Sprintf("participatedTeam%d", i)] = fmt.Sprintf("is:issue is:open team:%s", team)
 	}
 
-	raw, err := Search(client, issueSearchQuery, entries, parseIssueSearchJSON)
+	raw, err := Search(client, issueSearchQuery, entries, limit, parseIssueSearchJSON)
+	searchErr, err := splitSearchError(err)
 	if err != nil {
 		return nil, err
 	}
 
-	return parseIssueSearchResult(raw)
+	result, err := parseIssueSearchResult(raw)
+	if err != nil {
+		return nil, err
+	}
+	result.Errors = searchErr.tabErrors()
+	return result, nil
 }
 
 type IssueSearchResult struct {
@@ -49,6 +62,14 @@ type IssueSearchResult struct {
 	Assigned     []IssueSearchNode
 	Participated []IssueSearchNode
 	Custom       map[string][]IssueSearchNode
+	// Totals holds the number of matches per tab key reported by GitHub, which
+	// may exceed the number of fetched nodes. Missing keys mean all matches were fetched.
+	Totals map[string]int
+	// RateLimit is the lowest remaining quota observed, with the total cost of the searches.
+	RateLimit RateLimit
+	// Errors holds the error of each tab key whose search failed. The nodes of
+	// such tabs are missing or, for participated, incomplete.
+	Errors map[string]error `json:"-"`
 }
 
 func MergeSearchIssuesResults(a, b *IssueSearchResult) *IssueSearchResult {
@@ -64,14 +85,80 @@ func MergeSearchIssuesResults(a, b *IssueSearchResult) *IssueSearchResult {
 	}
 
 	merged := &IssueSearchResult{
-		Created:      append(a.Created, b.Created...),
-		Assigned:     append(a.Assigned, b.Assigned...),
-		Participated: append(a.Participated, b.Participated...),
+		Created:      deduplicateIssueNodes(append(a.Created, b.Created...)),
+		Assigned:     deduplicateIssueNodes(append(a.Assigned, b.Assigned...)),
+		Participated: deduplicateIssueNodes(append(a.Participated, b.Participated...)),
 		Custom:       custom,
+		Totals:       make(map[string]int),
 	}
+
+	merged.Totals["created"] = mergeTotal(len(merged.Created), a.total("created"), len(a.Created), b.total("created"), len(b.Created))
+	merged.Totals["assigned"] = mergeTotal(len(merged.Assigned), a.total("assigned"), len(a.Assigned), b.total("assigned"), len(b.Assigned))
+	merged.Totals["participated"] = mergeTotal(len(merged.Participated), a.total("participated"), len(a.Participated), b.total("participated"), len(b.Participated))
+	for k, v := range custom {
+		merged.Totals[k] = mergeTotal(len(v), a.total(k), len(a.Custom[k]), b.total(k), len(b.Custom[k]))
+	}
+	merged.Errors = mergeErrors(a.Errors, b.Errors)
+	merged.RateLimit = mergeRateLimit(a.RateLimit, b.RateLimit)
 	return merged
 }
 
+// total returns the number of matches for key, or 0 if unknown.
+func (r *IssueSearchResult) total(key string) int {
+	return r.Totals[key]
+}
+
+// KeepFailedTabs returns a copy of r in which every tab whose search failed
+// holds the nodes and total of prev instead, so caching r does not replace the
+// last good results of a tab with the missing ones of a failed search.
+func (r *IssueSearchResult) KeepFailedTabs(prev *IssueSearchResult) *IssueSearchResult {
+	if prev == nil || len(r.Errors) == 0 {
+		return r
+	}
+	kept := *r
+	kept.Custom = maps.Clone(r.Custom)
+	kept.Totals = keepTotals(r.Totals, prev.Totals, r.Errors)
+	for key := range r.Errors {
+		kept.setTab(key, prev.tab(key))
+	}
+	return &kept
+}
+
+// tab returns the nodes of the tab with key.
+func (r *IssueSearchResult) tab(key string) []IssueSearchNode {
+	switch key {
+	case "created":
+		return r.Created
+	case "assigned":
+		return r.Assigned
+	case "participated":
+		return r.Participated
+	default:
+		return r.Custom[key]
+	}
+}
+
+// setTab replaces the nodes of the tab with key.
+func (r *IssueSearchResult) setTab(key string, nodes []IssueSearchNode) {
+	switch key {
+	case "created":
+		r.Created = nodes
+	case "assigned":
+		r.Assigned = nodes
+	case "participated":
+		r.Participated = nodes
+	default:
+		if nodes == nil {
+			delete(r.Custom, key)
+			return
+		}
+		if r.Custom == nil {
+			r.Custom = make(map[string][]IssueSearchNode)
+		}
+		r.Custom[key] = nodes
+	}
+}
+
 func parseIssueSearchJSON(data json.RawMessage) ([]IssueSearchNode, error) {
 	var sr struct {
 		Nodes []issueSearchRawNode `json:"nodes"`
@@ -82,10 +169,13 @@ func parseIssueSearchJSON(data json.RawMessage) ([]IssueSearchNode, error) {
 	return parseIssueSearchNodes(sr.Nodes), nil
 }
 
-const issueSearchQuery = `query($q: String!) {
-	result: search(query: $q, type: ISSUE, first: 50) {
+const issueSearchQuery = `query($q: String!, $first: Int!, $after: String) {
+	result: search(query: $q, type: ISSUE, first: $first, after: $after) {
+		issueCount
+		pageInfo { hasNextPage endCursor }
 		nodes {
 		... on Issue {
+			id
 			number
 			title
 			url
@@ -100,31 +190,47 @@ const issueSearchQuery = `query($q: String!) {
 			}
 		}
 	}
+	rateLimit { cost limit remaining resetAt }
 }`
 
-func parseIssueSearchResult(parsed map[string][]IssueSearchNode) (*IssueSearchResult, error) {
+func parseIssueSearchResult(parsed map[string]SearchPage[IssueSearchNode]) (*IssueSearchResult, error) {
 	defaultKeys := config.DefaultIssueKeys()
 	var participated []IssueSearchNode
+	var participatedRemaining int
 	custom := make(map[string][]IssueSearchNode)
+	totals := make(map[string]int)
+	var rateLimit RateLimit
 
-	for key, nodes := range parsed {
+	for key, page := range parsed {
+		rateLimit = mergeRateLimit(rateLimit, page.RateLimit)
 		switch {
 		case strings.HasPrefix(key, "participated"):
-			participated = append(participated, nodes...)
+			participated = append(participated, page.Nodes...)
+			participatedRemaining += page.remaining()
 		case !defaultKeys[key]:
-			custom[key] = nodes
+			custom[key] = page.Nodes
+			totals[key] = len(page.Nodes) + page.remaining()
+		default:
+			totals[key] = len(page.Nodes) + page.remaining()
 		}
 	}
 
+	participated = deduplicateIssueNodes(participated)
+	totals["participated"] = len(participated) + participatedRemaining
+
 	return &IssueSearchResult{
-		Created:      parsed["created"],
-		Assigned:     parsed["assigned"],
-		Participated: deduplicateIssueNodes(participated),
+		Created:      parsed["created"].Nodes,
+		Assigned:     parsed["assigned"].Nodes,
+		Participated: participated,
 		Custom:       custom,
+		Totals:       totals,
+		RateLimit:    rateLimit,
 	}, nil
 }
 
 type IssueSearchNode struct {
+	// ID is the GraphQL node ID, used to act on the issue.
+	ID             string
 	Number         int
 	Title          string
 	URL            string
@@ -141,10 +247,11 @@ type IssueSearchNode struct {
 }
 
 func (i *IssueSearchNode) RepositoryURL() string {
-	return fmt.Sprintf("https://api.github.com/repos/%s", i.Repository.NameWithOwner)
+	return repositoryAPIURL(HostFromURL(i.URL), i.Repository.NameWithOwner)
 }
 
 type issueSearchRawNode struct {
+	ID        string `json:"id"`
 	Number    int    `json:"number"`
 	Title     string `json:"title"`
 	URL       string `json:"url"`
@@ -172,6 +279,7 @@ func parseIssueSearchNodes(rawNodes []issueSearchRawNode) []IssueSearchNode {
 			continue
 		}
 		node := IssueSearchNode{
+			ID:        n.ID,
 			Number:    n.Number,
 			Title:     n.Title,
 			URL:       n.URL,
@@ -5,9 +5,9 @@ import (
 )
 
 func TestParseIssueSearchResult_CustomKeyPreserved(t *testing.T) {
-	parsed := map[string][]IssueSearchNode{
-		"created": {{Number: 1, Title: "Issue1"}},
-		"myTab":   {{Number: 2, Title: "Issue2"}},
+	parsed := map[string]SearchPage[IssueSearchNode]{
+		"created": {Nodes: []IssueSearchNode{{Number: 1, Title: "Issue1"}}},
+		"myTab":   {Nodes: []IssueSearchNode{{Number: 2, Title: "Issue2"}}},
 	}
 
 	result, err := parseIssueSearchResult(parsed)
@@ -28,10 +28,10 @@ func TestParseIssueSearchResult_CustomKeyPreserved(t *testing.T) {
 }
 
 func TestParseIssueSearchResult_NoCustomKeys(t *testing.T) {
-	parsed := map[string][]IssueSearchNode{
-		"created":          {{Number: 1}},
-		"assigned":         {{Number: 2}},
-		"participatedUser": {{Number: 3}},
+	parsed := map[string]SearchPage[IssueSearchNode]{
+		"created":          {Nodes: []IssueSearchNode{{Number: 1}}},
+		"assigned":         {Nodes: []IssueSearchNode{{Number: 2}}},
+		"participatedUser": {Nodes: []IssueSearchNode{{Number: 3}}},
 	}
 
 	result, err := parseIssueSearchResult(parsed)
@@ -47,6 +47,29 @@ func TestParseIssueSearchResult_NoCustomKeys(t *testing.T) {
 	}
 }
 
+func TestParseIssueSearchResult_Totals(t *testing.T) {
+	parsed := map[string]SearchPage[IssueSearchNode]{
+		"assigned":         {Nodes: []IssueSearchNode{{Number: 1, URL: "u1"}}, IssueCount: 70},
+		"participatedUser": {Nodes: []IssueSearchNode{{Number: 2, URL: "u2"}}, IssueCount: 1},
+		"bugs":             {Nodes: []IssueSearchNode{{Number: 3, URL: "u3"}}, IssueCount: 9},
+	}
+
+	result, err := parseIssueSearchResult(parsed)
+	if err != nil {
+		t.Fatalf("parseIssueSearchResult returned error: %v", err)
+	}
+
+	if got := result.Totals["assigned"]; got != 70 {
+		t.Errorf("Totals[assigned] = %d, want 70", got)
+	}
+	if got := result.Totals["participated"]; got != 1 {
+		t.Errorf("Totals[participated] = %d, want 1", got)
+	}
+	if got := result.Totals["bugs"]; got != 9 {
+		t.Errorf("Totals[bugs] = %d, want 9", got)
+	}
+}
+
 func TestMergeSearchIssuesResults_MergesCustom(t *testing.T) {
 	a := &IssueSearchResult{
 		Custom: map[string][]IssueSearchNode{
@@ -147,7 +170,7 @@ func TestIssueSearchNode_Fields(t *testing.T) {
 }
 
 func TestSearchIssues_EmptyUsernameWithTeams(t *testing.T) {
-	results, err := SearchIssuesTeams(nil, "", []string{"my-org/team-a"})
+	results, err := SearchIssuesTeams(nil, "", []string{"my-org/team-a"}, 0)
 
 	if err != nil {
 		t.Errorf("SearchIssues with empty username returned error: %v", err)
@@ -162,7 +185,7 @@ func TestSearchIssues_EmptyUsernameWithTeams(t *testing.T) {
 }
 
 func TestSearchIssues_EmptyEntries(t *testing.T) {
-	results, err := SearchIssues(nil, nil)
+	results, err := SearchIssues(nil, nil, 0)
 
 	if err != nil {
 		t.Errorf("SearchIssues with empty username returned error: %v", err)
@@ -0,0 +1,171 @@
+package gh
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// Label is a label of a repository.
+type Label struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+const repositoryLabelsQuery = `query($owner: String!, $name: String!, $after: String) {
+	repository(owner: $owner, name: $name) {
+		labels(first: 100, after: $after, orderBy: {field: NAME, direction: ASC}) {
+			nodes { id name }
+			pageInfo { hasNextPage endCursor }
+		}
+	}
+}`
+
+const addLabelsMutation = `mutation($id: ID!, $labels: [ID!]!) {
+	addLabelsToLabelable(input: {labelableId: $id, labelIds: $labels}) {
+		clientMutationId
+	}
+}`
+
+const removeLabelsMutation = `mutation($id: ID!, $labels: [ID!]!) {
+	removeLabelsFromLabelable(input: {labelableId: $id, labelIds: $labels}) {
+		clientMutationId
+	}
+}`
+
+const labelNamesQuery = `query($id: ID!) {
+	node(id: $id) {
+		... on Labelable {
+			labels(first: 100) { nodes { name } }
+		}
+	}
+}`
+
+// GetRepositoryLabels returns all the labels of the repository nameWithOwner,
+// e.g. "owner/repo", sorted by name, following pagination.
+func GetRepositoryLabels(client *api.GraphQLClient, nameWithOwner string) ([]Label, error) {
+	owner, name, ok := strings.Cut(nameWithOwner, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid repository %q", nameWithOwner)
+	}
+	var labels []Label
+	vars := map[string]interface{}{"owner": owner, "name": name}
+	for {
+		var resp struct {
+			Repository *struct {
+				Labels struct {
+					Nodes    []Label  `json:"nodes"`
+					PageInfo pageInfo `json:"pageInfo"`
+				} `json:"labels"`
+			} `json:"repository"`
+		}
+		if err := doWithRetry(client, repositoryLabelsQuery, vars, &resp); err != nil {
+			return nil, err
+		}
+		if resp.Repository == nil {
+			return nil, fmt.Errorf("repository %s not found", nameWithOwner)
+		}
+		labels = append(labels, resp.Repository.Labels.Nodes...)
+		if !resp.Repository.Labels.PageInfo.HasNextPage {
+			return labels, nil
+		}
+		vars["after"] = resp.Repository.Labels.PageInfo.EndCursor
+	}
+}
+
+// FindLabel returns the label named name, ignoring case.
+func FindLabel(labels []Label, name string) (Label, bool) {
+	for _, l := range labels {
+		if strings.EqualFold(l.Name, name) {
+			return l, true
+		}
+	}
+	return Label{}, false
+}
+
+// AddLabels adds the labels with node IDs labelIDs to the issue or pull
+// request with node ID id.
+func AddLabels(client *api.GraphQLClient, id string, labelIDs []string) error {
+	var resp struct{}
+	return doWithRetry(client, addLabelsMutation, map[string]interface{}{"id": id, "labels": labelIDs}, &resp)
+}
+
+// RemoveLabels removes the labels with node IDs labelIDs from the issue or
+// pull request with node ID id.
+func RemoveLabels(client *api.GraphQLClient, id string, labelIDs []string) error {
+	var resp struct{}
+	return doWithRetry(client, removeLabelsMutation, map[string]interface{}{"id": id, "labels": labelIDs}, &resp)
+}
+
+// GetLabelNames returns the names of the labels of the issue or pull request
+// with node ID id.
+func GetLabelNames(client *api.GraphQLClient, id string) ([]string, error) {
+	var resp struct {
+		Node *struct {
+			Labels *struct {
+				Nodes []struct {
+					Name string `json:"name"`
+				} `json:"nodes"`
+			} `json:"labels"`
+		} `json:"node"`
+	}
+	if err := doWithRetry(client, labelNamesQuery, map[string]interface{}{"id": id}, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Node == nil || resp.Node.Labels == nil {
+		return nil, errors.New("not found")
+	}
+	names := make([]string, 0, len(resp.Node.Labels.Nodes))
+	for _, l := range resp.Node.Labels.Nodes {
+		names = append(names, l.Name)
+	}
+	return names, nil
+}
+
+// AddLabelsByName adds the labels named names of the repository nameWithOwner
+// to the issue or pull request with node ID id.
+func AddLabelsByName(client *api.GraphQLClient, nameWithOwner, id string, names []string) error {
+	return ChangeLabelsByName(client, nameWithOwner, id, names, nil)
+}
+
+// ChangeLabelsByName adds the labels named add and removes those named
+// remove, all of the repository nameWithOwner, on the issue or pull request
+// with node ID id.
+func ChangeLabelsByName(client *api.GraphQLClient, nameWithOwner, id string, add, remove []string) error {
+	labels, err := GetRepositoryLabels(client, nameWithOwner)
+	if err != nil {
+		return err
+	}
+	addIDs, err := labelIDs(labels, nameWithOwner, add)
+	if err != nil {
+		return err
+	}
+	removeIDs, err := labelIDs(labels, nameWithOwner, remove)
+	if err != nil {
+		return err
+	}
+	if len(addIDs) > 0 {
+		if err := AddLabels(client, id, addIDs); err != nil {
+			return err
+		}
+	}
+	if len(removeIDs) > 0 {
+		return RemoveLabels(client, id, removeIDs)
+	}
+	return nil
+}
+
+// labelIDs returns the node IDs of the labels named names.
+func labelIDs(labels []Label, nameWithOwner string, names []string) ([]string, error) {
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		l, ok := FindLabel(labels, name)
+		if !ok {
+			return nil, fmt.Errorf("no label %q in %s", name, nameWithOwner)
+		}
+		ids = append(ids, l.ID)
+	}
+	return ids, nil
+}
@@ -0,0 +1,161 @@
+package gh
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetRepositoryLabels(t *testing.T) {
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			body := decodeGraphQLRequest(t, req)
+			if body.Variables["owner"] != "owner" || body.Variables["name"] != "repo" {
+				t.Errorf("variables = %v, want owner and repo", body.Variables)
+			}
+			return jsonResponse(`{"data":{"repository":{"labels":{"nodes":[{"id":"LA_1","name":"bug"},{"id":"LA_2","name":"docs"}]}}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	got, err := GetRepositoryLabels(client, "owner/repo")
+	if err != nil {
+		t.Fatalf("GetRepositoryLabels() error: %v", err)
+	}
+	want := []Label{{ID: "LA_1", Name: "bug"}, {ID: "LA_2", Name: "docs"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetRepositoryLabels() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGetRepositoryLabels_FollowsPagination(t *testing.T) {
+	var afters []interface{}
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			body := decodeGraphQLRequest(t, req)
+			afters = append(afters, body.Variables["after"])
+			if body.Variables["after"] == nil {
+				return jsonResponse(`{"data":{"repository":{"labels":{"nodes":[{"id":"LA_1","name":"bug"}],"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}}}`), nil
+			}
+			return jsonResponse(`{"data":{"repository":{"labels":{"nodes":[{"id":"LA_2","name":"docs"}],"pageInfo":{"hasNextPage":false,"endCursor":"c2"}}}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	got, err := GetRepositoryLabels(client, "owner/repo")
+	if err != nil {
+		t.Fatalf("GetRepositoryLabels() error: %v", err)
+	}
+	want := []Label{{ID: "LA_1", Name: "bug"}, {ID: "LA_2", Name: "docs"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetRepositoryLabels() = %+v, want %+v", got, want)
+	}
+	if len(afters) != 2 || afters[1] != "c1" {
+		t.Errorf("after cursors = %v, want [<nil> c1]", afters)
+	}
+}
+
+func TestGetRepositoryLabels_InvalidRepository(t *testing.T) {
+	client := newTestGraphQLClient(t, &mockTransport{})
+
+	if _, err := GetRepositoryLabels(client, "repo"); err == nil {
+		t.Error("GetRepositoryLabels() without an owner should return an error")
+	}
+}
+
+func TestFindLabel(t *testing.T) {
+	labels := []Label{{ID: "LA_1", Name: "Bug"}}
+
+	if got, ok := FindLabel(labels, "bug"); !ok || got.ID != "LA_1" {
+		t.Errorf("FindLabel(bug) = %+v, %v, want LA_1", got, ok)
+	}
+	if _, ok := FindLabel(labels, "docs"); ok {
+		t.Error("FindLabel(docs) should not find a missing label")
+	}
+}
+
+func TestAddLabels(t *testing.T) {
+	var got graphQLRequest
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			got = decodeGraphQLRequest(t, req)
+			return jsonResponse(`{"data":{}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	if err := AddLabels(client, "I_1", []string{"LA_1"}); err != nil {
+		t.Fatalf("AddLabels() error: %v", err)
+	}
+	labels, ok := got.Variables["labels"].([]any)
+	if !ok || got.Variables["id"] != "I_1" || len(labels) != 1 || labels[0] != "LA_1" {
+		t.Errorf("variables = %v, want id I_1 and labels [LA_1]", got.Variables)
+	}
+}
+
+func TestAddLabelsByName(t *testing.T) {
+	var requests []graphQLRequest
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, decodeGraphQLRequest(t, req))
+			return jsonResponse(`{"data":{"repository":{"labels":{"nodes":[{"id":"LA_1","name":"bug"}]}}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	if err := AddLabelsByName(client, "owner/repo", "I_1", []string{"Bug"}); err != nil {
+		t.Fatalf("AddLabelsByName() error: %v", err)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("made %d requests, want a query and a mutation", len(requests))
+	}
+	if labels, ok := requests[1].Variables["labels"].([]any); !ok || len(labels) != 1 || labels[0] != "LA_1" {
+		t.Errorf("labels = %v, want [LA_1]", requests[1].Variables["labels"])
+	}
+
+	if err := AddLabelsByName(client, "owner/repo", "I_1", []string{"docs"}); err == nil {
+		t.Error("AddLabelsByName() with an unknown label should return an error")
+	}
+}
+
+func TestGetLabelNames(t *testing.T) {
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(`{"data":{"node":{"labels":{"nodes":[{"name":"bug"},{"name":"triage"}]}}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	got, err := GetLabelNames(client, "I_1")
+	if err != nil {
+		t.Fatalf("GetLabelNames() error: %v", err)
+	}
+	if want := []string{"bug", "triage"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetLabelNames() = %v, want %v", got, want)
+	}
+}
+
+func TestChangeLabelsByName(t *testing.T) {
+	var requests []graphQLRequest
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, decodeGraphQLRequest(t, req))
+			return jsonResponse(`{"data":{"repository":{"labels":{"nodes":[{"id":"LA_1","name":"bug"},{"id":"LA_2","name":"triage"}]}}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	if err := ChangeLabelsByName(client, "owner/repo", "I_1", nil, []string{"triage"}); err != nil {
+		t.Fatalf("ChangeLabelsByName() error: %v", err)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("made %d requests, want a query and the remove mutation only", len(requests))
+	}
+	if !strings.Contains(requests[1].Query, "removeLabelsFromLabelable") {
+		t.Errorf("query = %q, want removeLabelsFromLabelable", requests[1].Query)
+	}
+	if labels, ok := requests[1].Variables["labels"].([]any); !ok || len(labels) != 1 || labels[0] != "LA_2" {
+		t.Errorf("labels = %v, want [LA_2]", requests[1].Variables["labels"])
+	}
+}
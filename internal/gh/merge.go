@@ -0,0 +1,85 @@
+package gh
+
+import (
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// MergeMethod is how a pull request is merged.
+type MergeMethod string
+
+const (
+	MergeMethodMerge  MergeMethod = "MERGE"
+	MergeMethodSquash MergeMethod = "SQUASH"
+	MergeMethodRebase MergeMethod = "REBASE"
+)
+
+// UpdateMethod is how a pull request branch is brought up to date with its base branch.
+type UpdateMethod string
+
+const (
+	UpdateMethodMerge  UpdateMethod = "MERGE"
+	UpdateMethodRebase UpdateMethod = "REBASE"
+)
+
+// Mergeability is whether a pull request can be merged, as computed by GitHub.
+type Mergeability struct {
+	// Mergeable is MERGEABLE, CONFLICTING or UNKNOWN while GitHub computes it.
+	Mergeable string `json:"mergeable"`
+	// MergeStateStatus is, among others, CLEAN, BEHIND, BLOCKED, DIRTY or UNSTABLE.
+	MergeStateStatus string `json:"mergeStateStatus"`
+}
+
+const mergeabilityQuery = `query($id: ID!) {
+	node(id: $id) {
+		... on PullRequest { mergeable mergeStateStatus }
+	}
+}`
+
+const mergePullRequestMutation = `mutation($id: ID!, $method: PullRequestMergeMethod!) {
+	mergePullRequest(input: {pullRequestId: $id, mergeMethod: $method}) {
+		pullRequest { state }
+	}
+}`
+
+const enableAutoMergeMutation = `mutation($id: ID!, $method: PullRequestMergeMethod!) {
+	enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method}) {
+		pullRequest { number }
+	}
+}`
+
+const updateBranchMutation = `mutation($id: ID!, $method: PullRequestBranchUpdateMethod!) {
+	updatePullRequestBranch(input: {pullRequestId: $id, updateMethod: $method}) {
+		pullRequest { number }
+	}
+}`
+
+// GetMergeability returns whether the pull request with node ID prID can be merged.
+func GetMergeability(client *api.GraphQLClient, prID string) (Mergeability, error) {
+	var resp struct {
+		Node Mergeability `json:"node"`
+	}
+	if err := doWithRetry(client, mergeabilityQuery, map[string]interface{}{"id": prID}, &resp); err != nil {
+		return Mergeability{}, err
+	}
+	return resp.Node, nil
+}
+
+// MergePullRequest merges the pull request with node ID prID.
+func MergePullRequest(client *api.GraphQLClient, prID string, method MergeMethod) error {
+	var resp struct{}
+	return doWithRetry(client, mergePullRequestMutation, map[string]interface{}{"id": prID, "method": string(method)}, &resp)
+}
+
+// EnableAutoMerge makes GitHub merge the pull request with node ID prID once
+// its requirements, such as required checks, are met.
+func EnableAutoMerge(client *api.GraphQLClient, prID string, method MergeMethod) error {
+	var resp struct{}
+	return doWithRetry(client, enableAutoMergeMutation, map[string]interface{}{"id": prID, "method": string(method)}, &resp)
+}
+
+// UpdatePullRequestBranch brings the branch of the pull request with node ID
+// prID up to date with its base branch.
+func UpdatePullRequestBranch(client *api.GraphQLClient, prID string, method UpdateMethod) error {
+	var resp struct{}
+	return doWithRetry(client, updateBranchMutation, map[string]interface{}{"id": prID, "method": string(method)}, &resp)
+}
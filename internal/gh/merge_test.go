@@ -0,0 +1,85 @@
+package gh
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+func TestGetMergeability(t *testing.T) {
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			body := decodeGraphQLRequest(t, req)
+			if body.Variables["id"] != "PR_1" {
+				t.Errorf("id = %v, want PR_1", body.Variables["id"])
+			}
+			return jsonResponse(`{"data":{"node":{"mergeable":"CONFLICTING","mergeStateStatus":"DIRTY"}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	got, err := GetMergeability(client, "PR_1")
+	if err != nil {
+		t.Fatalf("GetMergeability() error: %v", err)
+	}
+	want := Mergeability{Mergeable: "CONFLICTING", MergeStateStatus: "DIRTY"}
+	if got != want {
+		t.Errorf("GetMergeability() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMergeMutations(t *testing.T) {
+	tests := []struct {
+		name     string
+		call     func(client *api.GraphQLClient) error
+		mutation string
+	}{
+		{"merge", func(c *api.GraphQLClient) error { return MergePullRequest(c, "PR_1", MergeMethodSquash) }, "mergePullRequest"},
+		{"auto-merge", func(c *api.GraphQLClient) error { return EnableAutoMerge(c, "PR_1", MergeMethodSquash) }, "enablePullRequestAutoMerge"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got graphQLRequest
+			transport := &mockTransport{
+				handler: func(req *http.Request) (*http.Response, error) {
+					got = decodeGraphQLRequest(t, req)
+					return jsonResponse(`{"data":{}}`), nil
+				},
+			}
+			client := newTestGraphQLClient(t, transport)
+
+			if err := tt.call(client); err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if !strings.Contains(got.Query, tt.mutation) {
+				t.Errorf("query = %q, want %s mutation", got.Query, tt.mutation)
+			}
+			if got.Variables["id"] != "PR_1" || got.Variables["method"] != "SQUASH" {
+				t.Errorf("variables = %v, want id PR_1 and method SQUASH", got.Variables)
+			}
+		})
+	}
+}
+
+func TestUpdatePullRequestBranch(t *testing.T) {
+	var got graphQLRequest
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			got = decodeGraphQLRequest(t, req)
+			return jsonResponse(`{"data":{"updatePullRequestBranch":{"pullRequest":{"number":1}}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	if err := UpdatePullRequestBranch(client, "PR_1", UpdateMethodRebase); err != nil {
+		t.Fatalf("UpdatePullRequestBranch() error: %v", err)
+	}
+	if !strings.Contains(got.Query, "updatePullRequestBranch") {
+		t.Errorf("query = %q, want updatePullRequestBranch mutation", got.Query)
+	}
+	if got.Variables["id"] != "PR_1" || got.Variables["method"] != "REBASE" {
+		t.Errorf("variables = %v, want id PR_1 and method REBASE", got.Variables)
+	}
+}
@@ -3,27 +3,35 @@ package gh
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"strings"
 
 	"github.com/cli/go-gh/v2/pkg/api"
 	"github.com/snrsw/gh-own/internal/cistatus"
 	"github.com/snrsw/gh-own/internal/config"
+	"github.com/snrsw/gh-own/internal/mergestatus"
 )
 
-func SearchPRs(client *api.GraphQLClient, entries map[string]string) (*PRSearchResult, error) {
+func SearchPRs(client *api.GraphQLClient, entries map[string]string, limit int) (*PRSearchResult, error) {
 	if len(entries) == 0 {
 		return &PRSearchResult{Custom: make(map[string][]PRSearchNode)}, nil
 	}
 
-	raw, err := Search(client, prSearchQuery, entries, parsePRSearchJSON)
+	raw, err := Search(client, prSearchQuery, entries, limit, parsePRSearchJSON)
+	searchErr, err := splitSearchError(err)
 	if err != nil {
 		return nil, err
 	}
 
-	return parsePRSearchResult(raw)
+	result, err := parsePRSearchResult(raw)
+	if err != nil {
+		return nil, err
+	}
+	result.Errors = searchErr.tabErrors()
+	return result, nil
 }
 
-func SearchPRsTeams(client *api.GraphQLClient, username string, teams []string) (*PRSearchResult, error) {
+func SearchPRsTeams(client *api.GraphQLClient, username string, teams []string, limit int) (*PRSearchResult, error) {
 	if username == "" {
 		return &PRSearchResult{Custom: make(map[string][]PRSearchNode)}, nil
 	}
@@ -37,12 +45,18 @@ func SearchPRsTeams(client *api.GraphQLClient, username string, teams []string)
 		entries[fmt.Sprintf("participatedTeam%d", i)] = fmt.Sprintf("is:pr is:open team:%s", team)
 	}
 
-	raw, err := Search(client, prSearchQuery, entries, parsePRSearchJSON)
+	raw, err := Search(client, prSearchQuery, entries, limit, parsePRSearchJSON)
+	searchErr, err := splitSearchError(err)
 	if err != nil {
 		return nil, err
 	}
 
-	return parsePRSearchResult(raw)
+	result, err := parsePRSearchResult(raw)
+	if err != nil {
+		return nil, err
+	}
+	result.Errors = searchErr.tabErrors()
+	return result, nil
 }
 
 type PRSearchResult struct {
@@ -51,6 +65,14 @@ type PRSearchResult struct {
 	Participated    []PRSearchNode
 	ReviewRequested []PRSearchNode
 	Custom          map[string][]PRSearchNode
+	// Totals holds the number of matches per tab key reported by GitHub, which
+	// may exceed the number of fetched nodes. Missing keys mean all matches were fetched.
+	Totals map[string]int
+	// RateLimit is the lowest remaining quota observed, with the total cost of the searches.
+	RateLimit RateLimit
+	// Errors holds the error of each tab key whose search failed. The nodes of
+	// such tabs are missing or, for participated, incomplete.
+	Errors map[string]error `json:"-"`
 }
 
 func MergeSearchPRsResults(a, b *PRSearchResult) *PRSearchResult {
@@ -66,15 +88,86 @@ func MergeSearchPRsResults(a, b *PRSearchResult) *PRSearchResult {
 	}
 
 	merged := &PRSearchResult{
-		Created:         append(a.Created, b.Created...),
-		Assigned:        append(a.Assigned, b.Assigned...),
-		Participated:    append(a.Participated, b.Participated...),
-		ReviewRequested: append(a.ReviewRequested, b.ReviewRequested...),
+		Created:         deduplicatePRNodes(append(a.Created, b.Created...)),
+		Assigned:        deduplicatePRNodes(append(a.Assigned, b.Assigned...)),
+		Participated:    deduplicatePRNodes(append(a.Participated, b.Participated...)),
+		ReviewRequested: deduplicatePRNodes(append(a.ReviewRequested, b.ReviewRequested...)),
 		Custom:          custom,
+		Totals:          make(map[string]int),
 	}
+
+	merged.Totals["created"] = mergeTotal(len(merged.Created), a.total("created"), len(a.Created), b.total("created"), len(b.Created))
+	merged.Totals["assigned"] = mergeTotal(len(merged.Assigned), a.total("assigned"), len(a.Assigned), b.total("assigned"), len(b.Assigned))
+	merged.Totals["participated"] = mergeTotal(len(merged.Participated), a.total("participated"), len(a.Participated), b.total("participated"), len(b.Participated))
+	merged.Totals["reviewRequested"] = mergeTotal(len(merged.ReviewRequested), a.total("reviewRequested"), len(a.ReviewRequested), b.total("reviewRequested"), len(b.ReviewRequested))
+	for k, v := range custom {
+		merged.Totals[k] = mergeTotal(len(v), a.total(k), len(a.Custom[k]), b.total(k), len(b.Custom[k]))
+	}
+	merged.Errors = mergeErrors(a.Errors, b.Errors)
+	merged.RateLimit = mergeRateLimit(a.RateLimit, b.RateLimit)
 	return merged
 }
 
+// total returns the number of matches for key, or 0 if unknown.
+func (r *PRSearchResult) total(key string) int {
+	return r.Totals[key]
+}
+
+// KeepFailedTabs returns a copy of r in which every tab whose search failed
+// holds the nodes and total of prev instead, so caching r does not replace the
+// last good results of a tab with the missing ones of a failed search.
+func (r *PRSearchResult) KeepFailedTabs(prev *PRSearchResult) *PRSearchResult {
+	if prev == nil || len(r.Errors) == 0 {
+		return r
+	}
+	kept := *r
+	kept.Custom = maps.Clone(r.Custom)
+	kept.Totals = keepTotals(r.Totals, prev.Totals, r.Errors)
+	for key := range r.Errors {
+		kept.setTab(key, prev.tab(key))
+	}
+	return &kept
+}
+
+// tab returns the nodes of the tab with key.
+func (r *PRSearchResult) tab(key string) []PRSearchNode {
+	switch key {
+	case "created":
+		return r.Created
+	case "assigned":
+		return r.Assigned
+	case "participated":
+		return r.Participated
+	case "reviewRequested":
+		return r.ReviewRequested
+	default:
+		return r.Custom[key]
+	}
+}
+
+// setTab replaces the nodes of the tab with key.
+func (r *PRSearchResult) setTab(key string, nodes []PRSearchNode) {
+	switch key {
+	case "created":
+		r.Created = nodes
+	case "assigned":
+		r.Assigned = nodes
+	case "participated":
+		r.Participated = nodes
+	case "reviewRequested":
+		r.ReviewRequested = nodes
+	default:
+		if nodes == nil {
+			delete(r.Custom, key)
+			return
+		}
+		if r.Custom == nil {
+			r.Custom = make(map[string][]PRSearchNode)
+		}
+		r.Custom[key] = nodes
+	}
+}
+
 func parsePRSearchJSON(data json.RawMessage) ([]PRSearchNode, error) {
 	var sr struct {
 		Nodes []prSearchRawNode `json:"nodes"`
@@ -85,10 +178,13 @@ func parsePRSearchJSON(data json.RawMessage) ([]PRSearchNode, error) {
 	return parsePRSearchNodes(sr.Nodes), nil
 }
 
-const prSearchQuery = `query($q: String!) {
-	result: search(query: $q, type: ISSUE, first: 50) {
+const prSearchQuery = `query($q: String!, $first: Int!, $after: String) {
+	result: search(query: $q, type: ISSUE, first: $first, after: $after) {
+		issueCount
+		pageInfo { hasNextPage endCursor }
 		nodes {
 			... on PullRequest {
+				id
 				number
 				title
 				url
@@ -96,6 +192,8 @@ const prSearchQuery = `query($q: String!) {
 				updatedAt
 				createdAt
 				reviewDecision
+				mergeable
+				mergeStateStatus
 				author { login }
 				repository { nameWithOwner }
 				commits(last: 1) {
@@ -116,32 +214,48 @@ const prSearchQuery = `query($q: String!) {
 			}
 		}
 	}
+	rateLimit { cost limit remaining resetAt }
 }`
 
-func parsePRSearchResult(parsed map[string][]PRSearchNode) (*PRSearchResult, error) {
+func parsePRSearchResult(parsed map[string]SearchPage[PRSearchNode]) (*PRSearchResult, error) {
 	defaultKeys := config.DefaultPRKeys()
 	var participated []PRSearchNode
+	var participatedRemaining int
 	custom := make(map[string][]PRSearchNode)
+	totals := make(map[string]int)
+	var rateLimit RateLimit
 
-	for key, nodes := range parsed {
+	for key, page := range parsed {
+		rateLimit = mergeRateLimit(rateLimit, page.RateLimit)
 		switch {
 		case strings.HasPrefix(key, "participated"):
-			participated = append(participated, nodes...)
+			participated = append(participated, page.Nodes...)
+			participatedRemaining += page.remaining()
 		case !defaultKeys[key]:
-			custom[key] = nodes
+			custom[key] = page.Nodes
+			totals[key] = len(page.Nodes) + page.remaining()
+		default:
+			totals[key] = len(page.Nodes) + page.remaining()
 		}
 	}
 
+	participated = deduplicatePRNodes(participated)
+	totals["participated"] = len(participated) + participatedRemaining
+
 	return &PRSearchResult{
-		Created:         parsed["created"],
-		Assigned:        parsed["assigned"],
-		Participated:    deduplicatePRNodes(participated),
-		ReviewRequested: parsed["reviewRequested"],
+		Created:         parsed["created"].Nodes,
+		Assigned:        parsed["assigned"].Nodes,
+		Participated:    participated,
+		ReviewRequested: parsed["reviewRequested"].Nodes,
 		Custom:          custom,
+		Totals:          totals,
+		RateLimit:       rateLimit,
 	}, nil
 }
 
 type PRSearchNode struct {
+	// ID is the GraphQL node ID, used to act on the pull request.
+	ID             string
 	Number         int
 	Title          string
 	URL            string
@@ -150,8 +264,12 @@ type PRSearchNode struct {
 	CreatedAt      string
 	StatusState    string
 	ReviewDecision string
-	LatestActivity LatestActivity
-	Author         struct {
+	// Mergeable and MergeStateStatus are as computed by GitHub, which may
+	// report UNKNOWN until it has checked the branch.
+	Mergeable        string
+	MergeStateStatus string
+	LatestActivity   LatestActivity
+	Author           struct {
 		Login string
 	}
 	Repository struct {
@@ -163,19 +281,26 @@ func (p *PRSearchNode) CIStatus() cistatus.CIStatus {
 	return cistatus.ParseState(p.StatusState)
 }
 
+func (p *PRSearchNode) MergeStatus() mergestatus.MergeStatus {
+	return mergestatus.Parse(p.Mergeable, p.MergeStateStatus)
+}
+
 func (p *PRSearchNode) RepositoryURL() string {
-	return fmt.Sprintf("https://api.github.com/repos/%s", p.Repository.NameWithOwner)
+	return repositoryAPIURL(HostFromURL(p.URL), p.Repository.NameWithOwner)
 }
 
 type prSearchRawNode struct {
-	Number         int    `json:"number"`
-	Title          string `json:"title"`
-	URL            string `json:"url"`
-	IsDraft        bool   `json:"isDraft"`
-	UpdatedAt      string `json:"updatedAt"`
-	CreatedAt      string `json:"createdAt"`
-	ReviewDecision string `json:"reviewDecision"`
-	Author         struct {
+	ID               string `json:"id"`
+	Number           int    `json:"number"`
+	Title            string `json:"title"`
+	URL              string `json:"url"`
+	IsDraft          bool   `json:"isDraft"`
+	UpdatedAt        string `json:"updatedAt"`
+	CreatedAt        string `json:"createdAt"`
+	ReviewDecision   string `json:"reviewDecision"`
+	Mergeable        string `json:"mergeable"`
+	MergeStateStatus string `json:"mergeStateStatus"`
+	Author           struct {
 		Login string `json:"login"`
 	} `json:"author"`
 	Repository struct {
@@ -218,13 +343,16 @@ func parsePRSearchNodes(rawNodes []prSearchRawNode) []PRSearchNode {
 			continue
 		}
 		node := PRSearchNode{
-			Number:         n.Number,
-			Title:          n.Title,
-			URL:            n.URL,
-			IsDraft:        n.IsDraft,
-			UpdatedAt:      n.UpdatedAt,
-			CreatedAt:      n.CreatedAt,
-			ReviewDecision: n.ReviewDecision,
+			ID:               n.ID,
+			Number:           n.Number,
+			Title:            n.Title,
+			URL:              n.URL,
+			IsDraft:          n.IsDraft,
+			UpdatedAt:        n.UpdatedAt,
+			CreatedAt:        n.CreatedAt,
+			ReviewDecision:   n.ReviewDecision,
+			Mergeable:        n.Mergeable,
+			MergeStateStatus: n.MergeStateStatus,
 		}
 		node.Author.Login = n.Author.Login
 		node.Repository.NameWithOwner = n.Repository.NameWithOwner
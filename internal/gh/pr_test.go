@@ -1,15 +1,18 @@
 package gh
 
 import (
+	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/snrsw/gh-own/internal/cistatus"
+	"github.com/snrsw/gh-own/internal/mergestatus"
 )
 
 func TestParsePRSearchResult_CustomKeyPreserved(t *testing.T) {
-	parsed := map[string][]PRSearchNode{
-		"created": {{Number: 1, Title: "PR1"}},
-		"myTab":   {{Number: 2, Title: "PR2"}},
+	parsed := map[string]SearchPage[PRSearchNode]{
+		"created": {Nodes: []PRSearchNode{{Number: 1, Title: "PR1"}}},
+		"myTab":   {Nodes: []PRSearchNode{{Number: 2, Title: "PR2"}}},
 	}
 
 	result, err := parsePRSearchResult(parsed)
@@ -30,11 +33,11 @@ func TestParsePRSearchResult_CustomKeyPreserved(t *testing.T) {
 }
 
 func TestParsePRSearchResult_NoCustomKeys(t *testing.T) {
-	parsed := map[string][]PRSearchNode{
-		"created":          {{Number: 1}},
-		"assigned":         {{Number: 2}},
-		"participatedUser": {{Number: 3}},
-		"reviewRequested":  {{Number: 4}},
+	parsed := map[string]SearchPage[PRSearchNode]{
+		"created":          {Nodes: []PRSearchNode{{Number: 1}}},
+		"assigned":         {Nodes: []PRSearchNode{{Number: 2}}},
+		"participatedUser": {Nodes: []PRSearchNode{{Number: 3}}},
+		"reviewRequested":  {Nodes: []PRSearchNode{{Number: 4}}},
 	}
 
 	result, err := parsePRSearchResult(parsed)
@@ -50,6 +53,78 @@ func TestParsePRSearchResult_NoCustomKeys(t *testing.T) {
 	}
 }
 
+func TestParsePRSearchResult_Totals(t *testing.T) {
+	parsed := map[string]SearchPage[PRSearchNode]{
+		"created": {Nodes: []PRSearchNode{{Number: 1, URL: "u1"}}, IssueCount: 312},
+		"participatedUser": {
+			Nodes:      []PRSearchNode{{Number: 2, URL: "u2"}, {Number: 3, URL: "u3"}},
+			IssueCount: 5,
+		},
+		"participatedTeam0": {Nodes: []PRSearchNode{{Number: 3, URL: "u3"}}, IssueCount: 1},
+		"myTab":             {Nodes: []PRSearchNode{{Number: 4, URL: "u4"}}, IssueCount: 1},
+	}
+
+	result, err := parsePRSearchResult(parsed)
+	if err != nil {
+		t.Fatalf("parsePRSearchResult returned error: %v", err)
+	}
+
+	if got := result.Totals["created"]; got != 312 {
+		t.Errorf("Totals[created] = %d, want 312", got)
+	}
+	// 2 unique fetched nodes plus 3 not fetched from participatedUser.
+	if got := result.Totals["participated"]; got != 5 {
+		t.Errorf("Totals[participated] = %d, want 5", got)
+	}
+	if got := result.Totals["myTab"]; got != 1 {
+		t.Errorf("Totals[myTab] = %d, want 1", got)
+	}
+}
+
+func TestParsePRSearchResult_RateLimit(t *testing.T) {
+	parsed := map[string]SearchPage[PRSearchNode]{
+		"created":  {RateLimit: RateLimit{Cost: 1, Limit: 5000, Remaining: 4990}},
+		"assigned": {RateLimit: RateLimit{Cost: 2, Limit: 5000, Remaining: 4980}},
+	}
+
+	result, err := parsePRSearchResult(parsed)
+	if err != nil {
+		t.Fatalf("parsePRSearchResult returned error: %v", err)
+	}
+
+	if result.RateLimit.Cost != 3 {
+		t.Errorf("RateLimit.Cost = %d, want 3", result.RateLimit.Cost)
+	}
+	if result.RateLimit.Remaining != 4980 {
+		t.Errorf("RateLimit.Remaining = %d, want 4980", result.RateLimit.Remaining)
+	}
+}
+
+func TestMergeSearchPRsResults_Totals(t *testing.T) {
+	a := &PRSearchResult{
+		Created:      []PRSearchNode{{Number: 1, URL: "u1"}},
+		Participated: []PRSearchNode{{Number: 2, URL: "u2"}},
+		Totals:       map[string]int{"created": 100, "participated": 1},
+	}
+	b := &PRSearchResult{
+		Participated: []PRSearchNode{{Number: 2, URL: "u2"}, {Number: 3, URL: "u3"}},
+		Totals:       map[string]int{"participated": 4},
+	}
+
+	merged := MergeSearchPRsResults(a, b)
+
+	if got := merged.Totals["created"]; got != 100 {
+		t.Errorf("Totals[created] = %d, want 100", got)
+	}
+	if len(merged.Participated) != 2 {
+		t.Errorf("Participated has %d nodes, want 2 (deduplicated)", len(merged.Participated))
+	}
+	// 2 unique nodes plus 2 not fetched by b.
+	if got := merged.Totals["participated"]; got != 4 {
+		t.Errorf("Totals[participated] = %d, want 4", got)
+	}
+}
+
 func TestMergeSearchPRsResults_MergesCustom(t *testing.T) {
 	a := &PRSearchResult{
 		Custom: map[string][]PRSearchNode{
@@ -102,6 +177,42 @@ func TestMergeSearchPRsResults_DeduplicatesCustomByURL(t *testing.T) {
 	}
 }
 
+func TestPRSearchResult_KeepFailedTabs(t *testing.T) {
+	prev := &PRSearchResult{
+		Created:  []PRSearchNode{{Number: 1}},
+		Assigned: []PRSearchNode{{Number: 2}},
+		Custom:   map[string][]PRSearchNode{"myTab": {{Number: 3}}},
+		Totals:   map[string]int{"created": 70, "myTab": 1},
+	}
+	r := &PRSearchResult{
+		Assigned: []PRSearchNode{{Number: 4}},
+		Custom:   map[string][]PRSearchNode{},
+		Totals:   map[string]int{"assigned": 1},
+		Errors:   map[string]error{"created": errors.New("boom"), "myTab": errors.New("boom")},
+	}
+
+	kept := r.KeepFailedTabs(prev)
+
+	if len(kept.Created) != 1 || kept.Created[0].Number != 1 {
+		t.Errorf("Created = %v, want the previous nodes", kept.Created)
+	}
+	if len(kept.Custom["myTab"]) != 1 || kept.Custom["myTab"][0].Number != 3 {
+		t.Errorf("Custom[myTab] = %v, want the previous nodes", kept.Custom["myTab"])
+	}
+	if len(kept.Assigned) != 1 || kept.Assigned[0].Number != 4 {
+		t.Errorf("Assigned = %v, want the fetched nodes", kept.Assigned)
+	}
+	if kept.Totals["created"] != 70 || kept.Totals["assigned"] != 1 {
+		t.Errorf("Totals = %v, want created from prev and assigned fetched", kept.Totals)
+	}
+	if len(r.Created) != 0 || len(r.Custom) != 0 {
+		t.Error("KeepFailedTabs modified the receiver")
+	}
+	if got := r.KeepFailedTabs(nil); got != r {
+		t.Error("KeepFailedTabs(nil) should return the receiver")
+	}
+}
+
 func TestPRSearchResult_CIStatus(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -141,8 +252,51 @@ func TestPRSearchNode_RepositoryURL(t *testing.T) {
 	}
 }
 
+func TestPRSearchNode_RepositoryURL_EnterpriseHost(t *testing.T) {
+	pr := PRSearchNode{
+		URL: "https://ghe.example.com/owner/repo/pull/1",
+		Repository: struct {
+			NameWithOwner string
+		}{
+			NameWithOwner: "owner/repo",
+		},
+	}
+
+	expected := "https://ghe.example.com/api/v3/repos/owner/repo"
+	if got := pr.RepositoryURL(); got != expected {
+		t.Errorf("RepositoryURL() = %q, want %q", got, expected)
+	}
+}
+
+func TestSearchPRs_PartialFailure(t *testing.T) {
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			if decodeGraphQLRequest(t, req).Variables["q"] == "bad" {
+				return jsonResponse(`{"errors":[{"type":"INVALID","message":"invalid query"}]}`), nil
+			}
+			return jsonResponse(`{"data":{"result":{"issueCount":1,"pageInfo":{"hasNextPage":false},"nodes":[{"number":1,"url":"u1"}]}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	result, err := SearchPRs(client, map[string]string{"created": "is:pr", "needs-triage": "bad"}, 0)
+	if err != nil {
+		t.Fatalf("SearchPRs() error: %v, want partial result", err)
+	}
+
+	if len(result.Created) != 1 {
+		t.Errorf("got %d created PRs, want 1", len(result.Created))
+	}
+	if result.Errors["needs-triage"] == nil {
+		t.Errorf("Errors = %v, want an error for needs-triage", result.Errors)
+	}
+	if result.Errors["created"] != nil {
+		t.Errorf("Errors[created] = %v, want nil", result.Errors["created"])
+	}
+}
+
 func TestSearchPRs_EmptyEntries(t *testing.T) {
-	results, err := SearchPRs(nil, nil)
+	results, err := SearchPRs(nil, nil, 0)
 
 	if err != nil {
 		t.Errorf("SearchPRs with empty username returned error: %v", err)
@@ -163,7 +317,7 @@ func TestSearchPRs_EmptyEntries(t *testing.T) {
 }
 
 func TestSearchPRs_EmptyEntries_HasEmptyCustom(t *testing.T) {
-	results, err := SearchPRs(nil, nil)
+	results, err := SearchPRs(nil, nil, 0)
 
 	if err != nil {
 		t.Fatalf("SearchPRs returned error: %v", err)
@@ -178,7 +332,7 @@ func TestSearchPRs_EmptyEntries_HasEmptyCustom(t *testing.T) {
 }
 
 func TestSearchPRs_EmptyUsernameWithTeams(t *testing.T) {
-	results, err := SearchPRsTeams(nil, "", []string{"my-org/team-a"})
+	results, err := SearchPRsTeams(nil, "", []string{"my-org/team-a"}, 0)
 
 	if err != nil {
 		t.Errorf("SearchPRs with empty username returned error: %v", err)
@@ -194,6 +348,7 @@ func TestSearchPRs_EmptyUsernameWithTeams(t *testing.T) {
 
 func TestParsePRSearchNodes(t *testing.T) {
 	node1 := prSearchRawNode{
+		ID:        "PR_10",
 		Number:    10,
 		Title:     "Add feature",
 		URL:       "https://github.com/owner/repo/pull/10",
@@ -227,6 +382,9 @@ func TestParsePRSearchNodes(t *testing.T) {
 		t.Fatalf("expected 2 nodes, got %d", len(nodes))
 	}
 
+	if nodes[0].ID != "PR_10" {
+		t.Errorf("nodes[0].ID = %q, want %q", nodes[0].ID, "PR_10")
+	}
 	if nodes[0].Number != 10 {
 		t.Errorf("nodes[0].Number = %d, want 10", nodes[0].Number)
 	}
@@ -417,3 +575,19 @@ func TestParsePRSearchNodes_ReviewDecision(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePRSearchNodes_MergeStatus(t *testing.T) {
+	node := prSearchRawNode{Number: 1, Title: "Test", Mergeable: "MERGEABLE", MergeStateStatus: "BEHIND"}
+
+	nodes := parsePRSearchNodes([]prSearchRawNode{node})
+
+	if len(nodes) != 1 {
+		t.Fatalf("expected 1 node, got %d", len(nodes))
+	}
+	if nodes[0].Mergeable != "MERGEABLE" || nodes[0].MergeStateStatus != "BEHIND" {
+		t.Errorf("Mergeable, MergeStateStatus = %q, %q, want MERGEABLE, BEHIND", nodes[0].Mergeable, nodes[0].MergeStateStatus)
+	}
+	if got := nodes[0].MergeStatus(); got != mergestatus.MergeStatusBehind {
+		t.Errorf("MergeStatus() = %v, want %v", got, mergestatus.MergeStatusBehind)
+	}
+}
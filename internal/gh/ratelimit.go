@@ -0,0 +1,160 @@
+package gh
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// maxConcurrentSearches caps the number of search requests in flight at once
+// per client, across concurrent calls to Search, to stay clear of GitHub's
+// secondary rate limits.
+const maxConcurrentSearches = 4
+
+// searchLimiter is the semaphore shared by the Search calls in flight on one
+// client.
+type searchLimiter struct {
+	sem   chan struct{}
+	users int
+}
+
+var (
+	searchLimitersMu sync.Mutex
+	searchLimiters   = make(map[*api.GraphQLClient]*searchLimiter)
+)
+
+// acquireSearchLimiter returns the semaphore of client, creating it for the
+// first Search call on it. Each call must be paired with releaseSearchLimiter.
+func acquireSearchLimiter(client *api.GraphQLClient) chan struct{} {
+	searchLimitersMu.Lock()
+	defer searchLimitersMu.Unlock()
+	l, ok := searchLimiters[client]
+	if !ok {
+		l = &searchLimiter{sem: make(chan struct{}, maxConcurrentSearches)}
+		searchLimiters[client] = l
+	}
+	l.users++
+	return l.sem
+}
+
+// releaseSearchLimiter forgets the semaphore of client once no Search call
+// uses it anymore.
+func releaseSearchLimiter(client *api.GraphQLClient) {
+	searchLimitersMu.Lock()
+	defer searchLimitersMu.Unlock()
+	if l, ok := searchLimiters[client]; ok {
+		if l.users--; l.users == 0 {
+			delete(searchLimiters, client)
+		}
+	}
+}
+
+// maxRetries is the number of times a rate-limited request is retried.
+const maxRetries = 3
+
+// maxRetryDelay caps the wait before a single retry.
+const maxRetryDelay = time.Minute
+
+var (
+	retryBaseDelay = time.Second
+	sleep          = time.Sleep
+)
+
+// RateLimit is the GraphQL rate limit status reported by GitHub.
+type RateLimit struct {
+	// Cost is the number of points consumed by the requests that were made.
+	Cost      int    `json:"cost"`
+	Limit     int    `json:"limit"`
+	Remaining int    `json:"remaining"`
+	ResetAt   string `json:"resetAt"`
+}
+
+// Known reports whether the rate limit was reported by GitHub.
+func (r RateLimit) Known() bool {
+	return r.Limit > 0
+}
+
+// String returns a short summary such as "API 4812/5000, resets in 23m",
+// or "" if the rate limit is unknown.
+func (r RateLimit) String() string {
+	if !r.Known() {
+		return ""
+	}
+	s := fmt.Sprintf("API %d/%d", r.Remaining, r.Limit)
+	if reset, err := time.Parse(time.RFC3339, r.ResetAt); err == nil {
+		if d := time.Until(reset); d > 0 {
+			s += fmt.Sprintf(", resets in %dm", int(d.Round(time.Minute).Minutes()))
+		}
+	}
+	return s
+}
+
+// mergeRateLimit sums the cost of a and b and keeps the lower remaining quota.
+func mergeRateLimit(a, b RateLimit) RateLimit {
+	cost := a.Cost + b.Cost
+	merged := a
+	if !a.Known() || (b.Known() && b.Remaining < a.Remaining) {
+		merged = b
+	}
+	merged.Cost = cost
+	return merged
+}
+
+// doWithRetry runs a GraphQL request, retrying with exponential backoff when
+// GitHub reports that a rate limit was hit.
+func doWithRetry(client *api.GraphQLClient, gql string, vars map[string]interface{}, response interface{}) error {
+	for attempt := 0; ; attempt++ {
+		err := client.Do(gql, vars, response)
+		if err == nil || attempt >= maxRetries || !isRateLimited(err) {
+			return err
+		}
+		delay := retryDelay(err, attempt)
+		slog.Debug("rate limited, retrying", "attempt", attempt+1, "delay", delay, "error", err)
+		sleep(delay)
+	}
+}
+
+// isRateLimited reports whether err means a primary or secondary rate limit was hit.
+func isRateLimited(err error) bool {
+	var httpErr *api.HTTPError
+	if errors.As(err, &httpErr) {
+		switch httpErr.StatusCode {
+		case http.StatusTooManyRequests:
+			return true
+		case http.StatusForbidden:
+			return httpErr.Headers.Get("Retry-After") != "" ||
+				httpErr.Headers.Get("X-RateLimit-Remaining") == "0" ||
+				strings.Contains(strings.ToLower(httpErr.Message), "rate limit")
+		}
+		return false
+	}
+
+	var gqlErr *api.GraphQLError
+	if errors.As(err, &gqlErr) {
+		for _, e := range gqlErr.Errors {
+			if e.Type == "RATE_LIMITED" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// retryDelay returns how long to wait before retrying attempt, honoring the
+// Retry-After header when present.
+func retryDelay(err error, attempt int) time.Duration {
+	var httpErr *api.HTTPError
+	if errors.As(err, &httpErr) {
+		if secs, convErr := strconv.Atoi(httpErr.Headers.Get("Retry-After")); convErr == nil && secs >= 0 {
+			return min(time.Duration(secs)*time.Second, maxRetryDelay)
+		}
+	}
+	return min(retryBaseDelay<<attempt, maxRetryDelay)
+}
@@ -0,0 +1,279 @@
+package gh
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// stubSleep replaces sleep for the duration of the test and records the delays.
+func stubSleep(t *testing.T) *[]time.Duration {
+	t.Helper()
+	var delays []time.Duration
+	orig := sleep
+	sleep = func(d time.Duration) { delays = append(delays, d) }
+	t.Cleanup(func() { sleep = orig })
+	return &delays
+}
+
+func statusResponse(req *http.Request, code int, header http.Header) *http.Response {
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Set("Content-Type", "application/json")
+	return &http.Response{
+		StatusCode: code,
+		Body:       io.NopCloser(strings.NewReader(`{"message":"error"}`)),
+		Header:     header,
+		Request:    req,
+	}
+}
+
+func TestIsRateLimited(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"429", &api.HTTPError{StatusCode: 429}, true},
+		{"403 with Retry-After", &api.HTTPError{StatusCode: 403, Headers: http.Header{"Retry-After": []string{"5"}}}, true},
+		{"403 with exhausted quota", &api.HTTPError{StatusCode: 403, Headers: http.Header{"X-Ratelimit-Remaining": []string{"0"}}}, true},
+		{"403 secondary rate limit message", &api.HTTPError{StatusCode: 403, Message: "You have exceeded a secondary rate limit"}, true},
+		{"403 permission denied", &api.HTTPError{StatusCode: 403, Message: "Resource not accessible"}, false},
+		{"500", &api.HTTPError{StatusCode: 500}, false},
+		{"GraphQL RATE_LIMITED", &api.GraphQLError{Errors: []api.GraphQLErrorItem{{Type: "RATE_LIMITED"}}}, true},
+		{"GraphQL other", &api.GraphQLError{Errors: []api.GraphQLErrorItem{{Type: "INVALID"}}}, false},
+		{"wrapped", fmt.Errorf("search: %w", &api.HTTPError{StatusCode: 429}), true},
+		{"plain", fmt.Errorf("boom"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRateLimited(tt.err); got != tt.want {
+				t.Errorf("isRateLimited() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	retryAfter := &api.HTTPError{StatusCode: 429, Headers: http.Header{"Retry-After": []string{"7"}}}
+	if got := retryDelay(retryAfter, 0); got != 7*time.Second {
+		t.Errorf("retryDelay(Retry-After: 7) = %v, want 7s", got)
+	}
+
+	plain := &api.HTTPError{StatusCode: 429, Headers: http.Header{}}
+	if got := retryDelay(plain, 2); got != 4*retryBaseDelay {
+		t.Errorf("retryDelay(attempt 2) = %v, want %v", got, 4*retryBaseDelay)
+	}
+
+	huge := &api.HTTPError{StatusCode: 429, Headers: http.Header{"Retry-After": []string{"3600"}}}
+	if got := retryDelay(huge, 0); got != maxRetryDelay {
+		t.Errorf("retryDelay(Retry-After: 3600) = %v, want %v", got, maxRetryDelay)
+	}
+}
+
+func TestDoWithRetry_RetriesRateLimitedRequests(t *testing.T) {
+	delays := stubSleep(t)
+	calls := 0
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls < 3 {
+				return statusResponse(req, 429, nil), nil
+			}
+			return jsonResponse(`{"data":{"ok":true}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	var resp map[string]bool
+	if err := doWithRetry(client, "query", nil, &resp); err != nil {
+		t.Fatalf("doWithRetry() error: %v", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("API was called %d times, want 3", calls)
+	}
+	if len(*delays) != 2 || (*delays)[1] <= (*delays)[0] {
+		t.Errorf("delays = %v, want 2 increasing delays", *delays)
+	}
+	if !resp["ok"] {
+		t.Error("response was not decoded after retry")
+	}
+}
+
+func TestDoWithRetry_GivesUpAfterMaxRetries(t *testing.T) {
+	stubSleep(t)
+	calls := 0
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return statusResponse(req, 429, nil), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	var resp map[string]any
+	if err := doWithRetry(client, "query", nil, &resp); err == nil {
+		t.Fatal("expected error after exhausting retries, got nil")
+	}
+	if calls != maxRetries+1 {
+		t.Errorf("API was called %d times, want %d", calls, maxRetries+1)
+	}
+}
+
+func TestDoWithRetry_DoesNotRetryOtherErrors(t *testing.T) {
+	stubSleep(t)
+	calls := 0
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return statusResponse(req, 500, nil), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	var resp map[string]any
+	if err := doWithRetry(client, "query", nil, &resp); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if calls != 1 {
+		t.Errorf("API was called %d times, want 1", calls)
+	}
+}
+
+func TestSearch_LimitsConcurrency(t *testing.T) {
+	var inFlight, peak int32
+	var mu sync.Mutex
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			n := atomic.AddInt32(&inFlight, 1)
+			mu.Lock()
+			peak = max(peak, n)
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			return jsonResponse(`{"data":{"result":{"issueCount":0,"pageInfo":{"hasNextPage":false},"nodes":[]}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	entries := make(map[string]string, 12)
+	for i := range 12 {
+		entries[fmt.Sprintf("participatedTeam%d", i)] = "is:pr"
+	}
+
+	if _, err := Search(client, "query", entries, 0, parseTestNodes); err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+
+	if peak > maxConcurrentSearches {
+		t.Errorf("peak concurrent requests = %d, want at most %d", peak, maxConcurrentSearches)
+	}
+}
+
+func TestSearch_LimitsConcurrencyAcrossCalls(t *testing.T) {
+	var inFlight, peak int32
+	var mu sync.Mutex
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			n := atomic.AddInt32(&inFlight, 1)
+			mu.Lock()
+			peak = max(peak, n)
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			return jsonResponse(`{"data":{"result":{"issueCount":0,"pageInfo":{"hasNextPage":false},"nodes":[]}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	entries := make(map[string]string, 6)
+	for i := range 6 {
+		entries[fmt.Sprintf("participatedTeam%d", i)] = "is:pr"
+	}
+
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := Search(client, "query", entries, 0, parseTestNodes); err != nil {
+				t.Errorf("Search() error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak > maxConcurrentSearches {
+		t.Errorf("peak concurrent requests = %d, want at most %d across both calls", peak, maxConcurrentSearches)
+	}
+	if len(searchLimiters) != 0 {
+		t.Errorf("%d limiters left after the searches, want none", len(searchLimiters))
+	}
+}
+
+func TestSearch_TracksRateLimitCost(t *testing.T) {
+	calls := 0
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return jsonResponse(`{"data":{"result":{"issueCount":2,"pageInfo":{"hasNextPage":true,"endCursor":"c1"},"nodes":[{"number":1}]},"rateLimit":{"cost":1,"limit":5000,"remaining":4990,"resetAt":"2030-01-01T00:00:00Z"}}}`), nil
+			}
+			return jsonResponse(`{"data":{"result":{"issueCount":2,"pageInfo":{"hasNextPage":false},"nodes":[{"number":2}]},"rateLimit":{"cost":1,"limit":5000,"remaining":4989,"resetAt":"2030-01-01T00:00:00Z"}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	pages, err := Search(client, "query", map[string]string{"created": "is:pr"}, 10, parseTestNodes)
+	if err != nil {
+		t.Fatalf("Search() error: %v", err)
+	}
+
+	rl := pages["created"].RateLimit
+	if rl.Cost != 2 {
+		t.Errorf("RateLimit.Cost = %d, want 2", rl.Cost)
+	}
+	if rl.Remaining != 4989 {
+		t.Errorf("RateLimit.Remaining = %d, want 4989", rl.Remaining)
+	}
+}
+
+func TestMergeRateLimit(t *testing.T) {
+	a := RateLimit{Cost: 1, Limit: 5000, Remaining: 4000}
+	b := RateLimit{Cost: 2, Limit: 5000, Remaining: 3000}
+
+	got := mergeRateLimit(a, b)
+	if got.Cost != 3 || got.Remaining != 3000 {
+		t.Errorf("mergeRateLimit() = %+v, want cost 3 and remaining 3000", got)
+	}
+
+	got = mergeRateLimit(RateLimit{}, a)
+	if got.Remaining != 4000 || got.Limit != 5000 {
+		t.Errorf("mergeRateLimit(unknown, a) = %+v, want a", got)
+	}
+
+	got = mergeRateLimit(a, RateLimit{Cost: 5})
+	if got.Remaining != 4000 || got.Cost != 6 {
+		t.Errorf("mergeRateLimit(a, unknown) = %+v, want a with cost 6", got)
+	}
+}
+
+func TestRateLimit_String(t *testing.T) {
+	if got := (RateLimit{}).String(); got != "" {
+		t.Errorf("String() of unknown rate limit = %q, want empty", got)
+	}
+
+	rl := RateLimit{Limit: 5000, Remaining: 4812, ResetAt: time.Now().Add(23 * time.Minute).Format(time.RFC3339)}
+	if got := rl.String(); !strings.HasPrefix(got, "API 4812/5000, resets in ") {
+		t.Errorf("String() = %q, want prefix %q", got, "API 4812/5000, resets in ")
+	}
+}
@@ -0,0 +1,98 @@
+package gh
+
+import (
+	"errors"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// actionsAppSlug is the slug of the GitHub App that runs GitHub Actions.
+const actionsAppSlug = "github-actions"
+
+// FailedCheckSuites are the failed GitHub Actions check suites of the head
+// commit of a pull request.
+type FailedCheckSuites struct {
+	RepositoryID string
+	IDs          []string
+}
+
+const failedCheckSuitesQuery = `query($id: ID!) {
+	node(id: $id) {
+		... on PullRequest {
+			repository { id }
+			commits(last: 1) {
+				nodes {
+					commit {
+						checkSuites(first: 50) {
+							nodes { id conclusion app { slug } }
+						}
+					}
+				}
+			}
+		}
+	}
+}`
+
+const rerequestCheckSuiteMutation = `mutation($repositoryId: ID!, $checkSuiteId: ID!) {
+	rerequestCheckSuite(input: {repositoryId: $repositoryId, checkSuiteId: $checkSuiteId}) {
+		checkSuite { id }
+	}
+}`
+
+type checkSuitesRawNode struct {
+	Repository *struct {
+		ID string `json:"id"`
+	} `json:"repository"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				CheckSuites struct {
+					Nodes []struct {
+						ID         string `json:"id"`
+						Conclusion string `json:"conclusion"`
+						App        *struct {
+							Slug string `json:"slug"`
+						} `json:"app"`
+					} `json:"nodes"`
+				} `json:"checkSuites"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+// GetFailedCheckSuites returns the failed GitHub Actions check suites of the
+// head commit of the pull request with node ID prID.
+func GetFailedCheckSuites(client *api.GraphQLClient, prID string) (FailedCheckSuites, error) {
+	var resp struct {
+		Node *checkSuitesRawNode `json:"node"`
+	}
+	if err := doWithRetry(client, failedCheckSuitesQuery, map[string]interface{}{"id": prID}, &resp); err != nil {
+		return FailedCheckSuites{}, err
+	}
+	if resp.Node == nil || resp.Node.Repository == nil {
+		return FailedCheckSuites{}, errors.New("not found")
+	}
+
+	failed := FailedCheckSuites{RepositoryID: resp.Node.Repository.ID}
+	if len(resp.Node.Commits.Nodes) == 0 {
+		return failed, nil
+	}
+	for _, s := range resp.Node.Commits.Nodes[0].Commit.CheckSuites.Nodes {
+		if s.App == nil || s.App.Slug != actionsAppSlug {
+			continue
+		}
+		switch s.Conclusion {
+		case "FAILURE", "TIMED_OUT", "STARTUP_FAILURE":
+			failed.IDs = append(failed.IDs, s.ID)
+		}
+	}
+	return failed, nil
+}
+
+// RerequestCheckSuite makes GitHub run the check suite with node ID suiteID
+// of the repository with node ID repoID again.
+func RerequestCheckSuite(client *api.GraphQLClient, repoID, suiteID string) error {
+	var resp struct{}
+	vars := map[string]interface{}{"repositoryId": repoID, "checkSuiteId": suiteID}
+	return doWithRetry(client, rerequestCheckSuiteMutation, vars, &resp)
+}
@@ -0,0 +1,59 @@
+package gh
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetFailedCheckSuites(t *testing.T) {
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			body := decodeGraphQLRequest(t, req)
+			if body.Variables["id"] != "PR_1" {
+				t.Errorf("id = %v, want PR_1", body.Variables["id"])
+			}
+			return jsonResponse(`{"data":{"node":{
+				"repository":{"id":"R_1"},
+				"commits":{"nodes":[{"commit":{"checkSuites":{"nodes":[
+					{"id":"CS_1","conclusion":"FAILURE","app":{"slug":"github-actions"}},
+					{"id":"CS_2","conclusion":"SUCCESS","app":{"slug":"github-actions"}},
+					{"id":"CS_3","conclusion":"FAILURE","app":{"slug":"other-ci"}},
+					{"id":"CS_4","conclusion":"TIMED_OUT","app":{"slug":"github-actions"}}
+				]}}}]}
+			}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	got, err := GetFailedCheckSuites(client, "PR_1")
+	if err != nil {
+		t.Fatalf("GetFailedCheckSuites() error: %v", err)
+	}
+	want := FailedCheckSuites{RepositoryID: "R_1", IDs: []string{"CS_1", "CS_4"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetFailedCheckSuites() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRerequestCheckSuite(t *testing.T) {
+	var got graphQLRequest
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			got = decodeGraphQLRequest(t, req)
+			return jsonResponse(`{"data":{"rerequestCheckSuite":{"checkSuite":{"id":"CS_1"}}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	if err := RerequestCheckSuite(client, "R_1", "CS_1"); err != nil {
+		t.Fatalf("RerequestCheckSuite() error: %v", err)
+	}
+	if !strings.Contains(got.Query, "rerequestCheckSuite") {
+		t.Errorf("query = %q, want rerequestCheckSuite mutation", got.Query)
+	}
+	if got.Variables["repositoryId"] != "R_1" || got.Variables["checkSuiteId"] != "CS_1" {
+		t.Errorf("variables = %v, want repositoryId R_1 and checkSuiteId CS_1", got.Variables)
+	}
+}
@@ -0,0 +1,34 @@
+package gh
+
+import (
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// ReviewEvent is the kind of review submitted on a pull request.
+type ReviewEvent string
+
+const (
+	ReviewApprove        ReviewEvent = "APPROVE"
+	ReviewRequestChanges ReviewEvent = "REQUEST_CHANGES"
+	ReviewComment        ReviewEvent = "COMMENT"
+)
+
+const addPullRequestReviewMutation = `mutation($id: ID!, $event: PullRequestReviewEvent!, $body: String) {
+	addPullRequestReview(input: {pullRequestId: $id, event: $event, body: $body}) {
+		pullRequestReview { state }
+	}
+}`
+
+// AddPullRequestReview submits a review on the pull request with node ID prID.
+// body may be empty only when approving.
+func AddPullRequestReview(client *api.GraphQLClient, prID string, event ReviewEvent, body string) error {
+	vars := map[string]interface{}{
+		"id":    prID,
+		"event": string(event),
+	}
+	if body != "" {
+		vars["body"] = body
+	}
+	var resp struct{}
+	return doWithRetry(client, addPullRequestReviewMutation, vars, &resp)
+}
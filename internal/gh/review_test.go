@@ -0,0 +1,63 @@
+package gh
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAddPullRequestReview(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    ReviewEvent
+		body     string
+		wantBody any
+	}{
+		{"approve without body", ReviewApprove, "", nil},
+		{"request changes", ReviewRequestChanges, "Please add tests", "Please add tests"},
+		{"comment", ReviewComment, "Looks reasonable", "Looks reasonable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got graphQLRequest
+			transport := &mockTransport{
+				handler: func(req *http.Request) (*http.Response, error) {
+					got = decodeGraphQLRequest(t, req)
+					return jsonResponse(`{"data":{"addPullRequestReview":{"pullRequestReview":{"state":"APPROVED"}}}}`), nil
+				},
+			}
+			client := newTestGraphQLClient(t, transport)
+
+			if err := AddPullRequestReview(client, "PR_1", tt.event, tt.body); err != nil {
+				t.Fatalf("AddPullRequestReview() error: %v", err)
+			}
+
+			if !strings.Contains(got.Query, "addPullRequestReview") {
+				t.Errorf("query = %q, want addPullRequestReview mutation", got.Query)
+			}
+			if got.Variables["id"] != "PR_1" {
+				t.Errorf("id = %v, want PR_1", got.Variables["id"])
+			}
+			if got.Variables["event"] != string(tt.event) {
+				t.Errorf("event = %v, want %v", got.Variables["event"], tt.event)
+			}
+			if got.Variables["body"] != tt.wantBody {
+				t.Errorf("body = %v, want %v", got.Variables["body"], tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestAddPullRequestReview_Error(t *testing.T) {
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(`{"errors":[{"type":"UNPROCESSABLE","message":"Can not approve your own pull request"}]}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	err := AddPullRequestReview(client, "PR_1", ReviewApprove, "")
+	if err == nil || !strings.Contains(err.Error(), "Can not approve your own pull request") {
+		t.Errorf("AddPullRequestReview() error = %v, want the GraphQL error", err)
+	}
+}
@@ -0,0 +1,155 @@
+package gh
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+const userIDQuery = `query($login: String!) {
+	user(login: $login) { id }
+}`
+
+const teamIDQuery = `query($org: String!, $slug: String!) {
+	organization(login: $org) {
+		team(slug: $slug) { id }
+	}
+}`
+
+const requestReviewsMutation = `mutation($id: ID!, $users: [ID!], $teams: [ID!]) {
+	requestReviews(input: {pullRequestId: $id, userIds: $users, teamIds: $teams, union: true}) {
+		pullRequest { number }
+	}
+}`
+
+const setReviewersMutation = `mutation($id: ID!, $users: [ID!], $teams: [ID!]) {
+	requestReviews(input: {pullRequestId: $id, userIds: $users, teamIds: $teams, union: false}) {
+		pullRequest { number }
+	}
+}`
+
+const reviewRequestsQuery = `query($id: ID!) {
+	node(id: $id) {
+		... on PullRequest {
+			reviewRequests(first: 100) {
+				nodes {
+					requestedReviewer {
+						... on User { login }
+						... on Team { combinedSlug }
+					}
+				}
+			}
+		}
+	}
+}`
+
+// Reviewers are the node IDs of the users and teams asked to review a pull request.
+type Reviewers struct {
+	UserIDs []string
+	TeamIDs []string
+}
+
+// GetReviewers looks up the node IDs of reviewers given as user logins or
+// team slugs, e.g. "alice" or "acme/core".
+func GetReviewers(client *api.GraphQLClient, names []string) (Reviewers, error) {
+	var r Reviewers
+	for _, name := range names {
+		if org, slug, ok := strings.Cut(name, "/"); ok {
+			id, err := teamID(client, org, slug)
+			if err != nil {
+				return Reviewers{}, err
+			}
+			r.TeamIDs = append(r.TeamIDs, id)
+			continue
+		}
+		id, err := userID(client, name)
+		if err != nil {
+			return Reviewers{}, err
+		}
+		r.UserIDs = append(r.UserIDs, id)
+	}
+	return r, nil
+}
+
+func userID(client *api.GraphQLClient, login string) (string, error) {
+	var resp struct {
+		User *struct {
+			ID string `json:"id"`
+		} `json:"user"`
+	}
+	if err := doWithRetry(client, userIDQuery, map[string]interface{}{"login": login}, &resp); err != nil {
+		return "", err
+	}
+	if resp.User == nil {
+		return "", fmt.Errorf("user %s not found", login)
+	}
+	return resp.User.ID, nil
+}
+
+func teamID(client *api.GraphQLClient, org, slug string) (string, error) {
+	var resp struct {
+		Organization *struct {
+			Team *struct {
+				ID string `json:"id"`
+			} `json:"team"`
+		} `json:"organization"`
+	}
+	if err := doWithRetry(client, teamIDQuery, map[string]interface{}{"org": org, "slug": slug}, &resp); err != nil {
+		return "", err
+	}
+	if resp.Organization == nil || resp.Organization.Team == nil {
+		return "", fmt.Errorf("team %s/%s not found", org, slug)
+	}
+	return resp.Organization.Team.ID, nil
+}
+
+// RequestReviews asks reviewers to review the pull request with node ID prID,
+// keeping the reviewers already requested.
+func RequestReviews(client *api.GraphQLClient, prID string, reviewers Reviewers) error {
+	vars := map[string]interface{}{"id": prID, "users": reviewers.UserIDs, "teams": reviewers.TeamIDs}
+	var resp struct{}
+	return doWithRetry(client, requestReviewsMutation, vars, &resp)
+}
+
+// SetReviewers asks exactly reviewers to review the pull request with node ID
+// prID, removing the review requests of anyone else.
+func SetReviewers(client *api.GraphQLClient, prID string, reviewers Reviewers) error {
+	vars := map[string]interface{}{"id": prID, "users": reviewers.UserIDs, "teams": reviewers.TeamIDs}
+	var resp struct{}
+	return doWithRetry(client, setReviewersMutation, vars, &resp)
+}
+
+// GetReviewRequests returns the users and teams asked to review the pull
+// request with node ID prID, as logins and team slugs, e.g. "acme/core".
+func GetReviewRequests(client *api.GraphQLClient, prID string) ([]string, error) {
+	var resp struct {
+		Node *struct {
+			ReviewRequests *struct {
+				Nodes []struct {
+					RequestedReviewer struct {
+						Login        string `json:"login"`
+						CombinedSlug string `json:"combinedSlug"`
+					} `json:"requestedReviewer"`
+				} `json:"nodes"`
+			} `json:"reviewRequests"`
+		} `json:"node"`
+	}
+	if err := doWithRetry(client, reviewRequestsQuery, map[string]interface{}{"id": prID}, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Node == nil || resp.Node.ReviewRequests == nil {
+		return nil, errors.New("not found")
+	}
+	var names []string
+	for _, n := range resp.Node.ReviewRequests.Nodes {
+		switch r := n.RequestedReviewer; {
+		case r.Login != "":
+			names = append(names, r.Login)
+		case r.CombinedSlug != "":
+			names = append(names, r.CombinedSlug)
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,100 @@
+package gh
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetReviewers(t *testing.T) {
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			body := decodeGraphQLRequest(t, req)
+			switch {
+			case body.Variables["login"] == "alice":
+				return jsonResponse(`{"data":{"user":{"id":"U_1"}}}`), nil
+			case body.Variables["org"] == "acme" && body.Variables["slug"] == "core":
+				return jsonResponse(`{"data":{"organization":{"team":{"id":"T_1"}}}}`), nil
+			}
+			return jsonResponse(`{"data":{"user":null}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	got, err := GetReviewers(client, []string{"alice", "acme/core"})
+	if err != nil {
+		t.Fatalf("GetReviewers() error: %v", err)
+	}
+	want := Reviewers{UserIDs: []string{"U_1"}, TeamIDs: []string{"T_1"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetReviewers() = %+v, want %+v", got, want)
+	}
+
+	if _, err := GetReviewers(client, []string{"ghost"}); err == nil || !strings.Contains(err.Error(), "ghost") {
+		t.Errorf("GetReviewers() of an unknown user error = %v, want it named", err)
+	}
+}
+
+func TestRequestReviews(t *testing.T) {
+	var got graphQLRequest
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			got = decodeGraphQLRequest(t, req)
+			return jsonResponse(`{"data":{"requestReviews":{"pullRequest":{"number":1}}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	if err := RequestReviews(client, "PR_1", Reviewers{UserIDs: []string{"U_1"}}); err != nil {
+		t.Fatalf("RequestReviews() error: %v", err)
+	}
+	if !strings.Contains(got.Query, "union: true") {
+		t.Error("RequestReviews() should keep the reviewers already requested")
+	}
+	users, ok := got.Variables["users"].([]any)
+	if !ok || got.Variables["id"] != "PR_1" || len(users) != 1 || users[0] != "U_1" {
+		t.Errorf("variables = %v, want id PR_1 and users [U_1]", got.Variables)
+	}
+}
+
+func TestGetReviewRequests(t *testing.T) {
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(`{"data":{"node":{"reviewRequests":{"nodes":[
+				{"requestedReviewer":{"login":"alice"}},
+				{"requestedReviewer":{"combinedSlug":"acme/core"}}
+			]}}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	got, err := GetReviewRequests(client, "PR_1")
+	if err != nil {
+		t.Fatalf("GetReviewRequests() error: %v", err)
+	}
+	if want := []string{"alice", "acme/core"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetReviewRequests() = %v, want %v", got, want)
+	}
+}
+
+func TestSetReviewers(t *testing.T) {
+	var got graphQLRequest
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			got = decodeGraphQLRequest(t, req)
+			return jsonResponse(`{"data":{"requestReviews":{"pullRequest":{"number":1}}}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	if err := SetReviewers(client, "PR_1", Reviewers{TeamIDs: []string{"T_1"}}); err != nil {
+		t.Fatalf("SetReviewers() error: %v", err)
+	}
+	if !strings.Contains(got.Query, "union: false") {
+		t.Error("SetReviewers() should replace the reviewers already requested")
+	}
+	if teams, ok := got.Variables["teams"].([]any); !ok || len(teams) != 1 || teams[0] != "T_1" {
+		t.Errorf("teams = %v, want [T_1]", got.Variables["teams"])
+	}
+}
@@ -0,0 +1,73 @@
+package gh
+
+import (
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// IssueCloseReason is why an issue is closed.
+type IssueCloseReason string
+
+const (
+	IssueCloseCompleted  IssueCloseReason = "COMPLETED"
+	IssueCloseNotPlanned IssueCloseReason = "NOT_PLANNED"
+)
+
+const closePullRequestMutation = `mutation($id: ID!) {
+	closePullRequest(input: {pullRequestId: $id}) {
+		pullRequest { state }
+	}
+}`
+
+const closeIssueMutation = `mutation($id: ID!, $reason: IssueClosedStateReason) {
+	closeIssue(input: {issueId: $id, stateReason: $reason}) {
+		issue { state }
+	}
+}`
+
+const reopenIssueMutation = `mutation($id: ID!) {
+	reopenIssue(input: {issueId: $id}) {
+		issue { state }
+	}
+}`
+
+const markReadyForReviewMutation = `mutation($id: ID!) {
+	markPullRequestReadyForReview(input: {pullRequestId: $id}) {
+		pullRequest { isDraft }
+	}
+}`
+
+const convertToDraftMutation = `mutation($id: ID!) {
+	convertPullRequestToDraft(input: {pullRequestId: $id}) {
+		pullRequest { isDraft }
+	}
+}`
+
+// ClosePullRequest closes the pull request with node ID prID without merging it.
+func ClosePullRequest(client *api.GraphQLClient, prID string) error {
+	var resp struct{}
+	return doWithRetry(client, closePullRequestMutation, map[string]interface{}{"id": prID}, &resp)
+}
+
+// CloseIssue closes the issue with node ID issueID for reason.
+func CloseIssue(client *api.GraphQLClient, issueID string, reason IssueCloseReason) error {
+	var resp struct{}
+	return doWithRetry(client, closeIssueMutation, map[string]interface{}{"id": issueID, "reason": reason}, &resp)
+}
+
+// ReopenIssue reopens the closed issue with node ID issueID.
+func ReopenIssue(client *api.GraphQLClient, issueID string) error {
+	var resp struct{}
+	return doWithRetry(client, reopenIssueMutation, map[string]interface{}{"id": issueID}, &resp)
+}
+
+// MarkReadyForReview marks the draft pull request with node ID prID as ready for review.
+func MarkReadyForReview(client *api.GraphQLClient, prID string) error {
+	var resp struct{}
+	return doWithRetry(client, markReadyForReviewMutation, map[string]interface{}{"id": prID}, &resp)
+}
+
+// ConvertToDraft converts the pull request with node ID prID back to a draft.
+func ConvertToDraft(client *api.GraphQLClient, prID string) error {
+	var resp struct{}
+	return doWithRetry(client, convertToDraftMutation, map[string]interface{}{"id": prID}, &resp)
+}
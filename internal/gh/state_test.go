@@ -0,0 +1,63 @@
+package gh
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+func TestStateMutations(t *testing.T) {
+	tests := []struct {
+		name     string
+		call     func(client *api.GraphQLClient) error
+		mutation string
+	}{
+		{"close pull request", func(c *api.GraphQLClient) error { return ClosePullRequest(c, "ID_1") }, "closePullRequest"},
+		{"close issue", func(c *api.GraphQLClient) error { return CloseIssue(c, "ID_1", IssueCloseNotPlanned) }, "closeIssue"},
+		{"reopen issue", func(c *api.GraphQLClient) error { return ReopenIssue(c, "ID_1") }, "reopenIssue"},
+		{"ready for review", func(c *api.GraphQLClient) error { return MarkReadyForReview(c, "ID_1") }, "markPullRequestReadyForReview"},
+		{"convert to draft", func(c *api.GraphQLClient) error { return ConvertToDraft(c, "ID_1") }, "convertPullRequestToDraft"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got graphQLRequest
+			transport := &mockTransport{
+				handler: func(req *http.Request) (*http.Response, error) {
+					got = decodeGraphQLRequest(t, req)
+					return jsonResponse(`{"data":{}}`), nil
+				},
+			}
+			client := newTestGraphQLClient(t, transport)
+
+			if err := tt.call(client); err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if !strings.Contains(got.Query, tt.mutation) {
+				t.Errorf("query = %q, want %s mutation", got.Query, tt.mutation)
+			}
+			if got.Variables["id"] != "ID_1" {
+				t.Errorf("id = %v, want ID_1", got.Variables["id"])
+			}
+		})
+	}
+}
+
+func TestCloseIssue_Reason(t *testing.T) {
+	var got graphQLRequest
+	transport := &mockTransport{
+		handler: func(req *http.Request) (*http.Response, error) {
+			got = decodeGraphQLRequest(t, req)
+			return jsonResponse(`{"data":{}}`), nil
+		},
+	}
+	client := newTestGraphQLClient(t, transport)
+
+	if err := CloseIssue(client, "I_1", IssueCloseNotPlanned); err != nil {
+		t.Fatalf("CloseIssue() error: %v", err)
+	}
+	if got.Variables["reason"] != "NOT_PLANNED" {
+		t.Errorf("reason = %v, want NOT_PLANNED", got.Variables["reason"])
+	}
+}
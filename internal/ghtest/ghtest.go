@@ -0,0 +1,75 @@
+// Package ghtest provides a fake GitHub GraphQL API for testing actions.
+package ghtest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/snrsw/gh-own/internal/ui"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Request is a GraphQL request recorded by GitHub.
+type Request struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// GitHub answers GraphQL requests with canned responses, in order, and
+// records the requests.
+type GitHub struct {
+	t         *testing.T
+	responses []string
+	Requests  []Request
+}
+
+// NewGitHub returns a fake answering with responses, in order, and with an
+// empty result once they run out.
+func NewGitHub(t *testing.T, responses ...string) *GitHub {
+	return &GitHub{t: t, responses: responses}
+}
+
+// Client is a gh.ClientFunc returning clients backed by g.
+func (g *GitHub) Client(host string) (*api.GraphQLClient, error) {
+	return api.NewGraphQLClient(api.ClientOptions{
+		Host:      host,
+		AuthToken: "test-token",
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			var r Request
+			if err := json.NewDecoder(req.Body).Decode(&r); err != nil {
+				g.t.Fatalf("failed to decode GraphQL request: %v", err)
+			}
+			g.Requests = append(g.Requests, r)
+			body := `{"data":{}}`
+			if i := len(g.Requests) - 1; i < len(g.responses) {
+				body = g.responses[i]
+			}
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+			}, nil
+		}),
+	})
+}
+
+// FindAction returns the action bound to key, failing the test if there is none.
+func FindAction(t *testing.T, actions []ui.Action, key string) ui.Action {
+	t.Helper()
+	for _, a := range actions {
+		if a.Key == key {
+			return a
+		}
+	}
+	t.Fatalf("no action bound to %q", key)
+	return ui.Action{}
+}
@@ -0,0 +1,235 @@
+// Package issue provides functionality to handle GitHub issues owned by a user.
+package issue
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/snrsw/gh-own/internal/gh"
+	"github.com/snrsw/gh-own/internal/ui"
+)
+
+// States of an issue, as reported by the API.
+const (
+	stateOpen   = "OPEN"
+	stateClosed = "CLOSED"
+)
+
+// closeReasons maps the choices of the close action to why the issue is closed.
+var closeReasons = map[string]gh.IssueCloseReason{
+	"completed":   gh.IssueCloseCompleted,
+	"not planned": gh.IssueCloseNotPlanned,
+}
+
+// Actions returns the actions offered on issue items.
+func Actions(client gh.ClientFunc) []ui.Action {
+	return []ui.Action{
+		labelAction(client),
+		assignAction(client),
+		commentAction(client),
+		closeAction(client),
+		reopenAction(client),
+	}
+}
+
+// labelAction adds and removes labels of the selected or marked issues,
+// picked from the labels of the repository.
+func labelAction(client gh.ClientFunc) ui.Action {
+	return ui.Action{
+		Key:     "L",
+		Help:    "label",
+		Bulk:    true,
+		Applies: func(_ string, it ui.Item) bool { return actionable(it) },
+		Options: func(it ui.Item) (ui.Options, error) {
+			i := issueOf(it)
+			c, err := client(i.host())
+			if err != nil {
+				return ui.Options{}, err
+			}
+			labels, err := gh.GetRepositoryLabels(c, i.repositoryFullName())
+			if err != nil {
+				return ui.Options{}, err
+			}
+			current, err := gh.GetLabelNames(c, i.NodeID)
+			if err != nil {
+				return ui.Options{}, err
+			}
+			all := make([]string, 0, len(labels))
+			for _, l := range labels {
+				all = append(all, l.Name)
+			}
+			return ui.Options{All: all, Selected: current}, nil
+		},
+		Run: func(it ui.Item, in ui.ActionInput) (ui.ActionResult, error) {
+			i := issueOf(it)
+			c, err := client(i.host())
+			if err != nil {
+				return ui.ActionResult{}, err
+			}
+			if err := gh.ChangeLabelsByName(c, i.repositoryFullName(), i.NodeID, in.Added, in.Removed); err != nil {
+				return ui.ActionResult{}, err
+			}
+			return ui.ActionResult{Status: "Updated labels of " + i.reference()}, nil
+		},
+	}
+}
+
+// assignAction assigns and unassigns users of the selected or marked issues,
+// picked from the users assignable in the repository.
+func assignAction(client gh.ClientFunc) ui.Action {
+	return ui.Action{
+		Key:     "A",
+		Help:    "assign",
+		Bulk:    true,
+		Applies: func(_ string, it ui.Item) bool { return actionable(it) },
+		Options: func(it ui.Item) (ui.Options, error) {
+			i := issueOf(it)
+			c, err := client(i.host())
+			if err != nil {
+				return ui.Options{}, err
+			}
+			users, err := gh.GetAssignableUsers(c, i.repositoryFullName())
+			if err != nil {
+				return ui.Options{}, err
+			}
+			current, err := gh.GetAssignees(c, i.NodeID)
+			if err != nil {
+				return ui.Options{}, err
+			}
+			return ui.Options{All: users, Selected: current}, nil
+		},
+		Run: func(it ui.Item, in ui.ActionInput) (ui.ActionResult, error) {
+			i := issueOf(it)
+			c, err := client(i.host())
+			if err != nil {
+				return ui.ActionResult{}, err
+			}
+			if err := gh.ChangeAssignees(c, i.NodeID, in.Added, in.Removed); err != nil {
+				return ui.ActionResult{}, err
+			}
+			return ui.ActionResult{Status: "Updated assignees of " + i.reference()}, nil
+		},
+	}
+}
+
+// commentAction posts a comment on the selected issue, composed from a
+// quote of its latest comment.
+func commentAction(client gh.ClientFunc) ui.Action {
+	return ui.Action{
+		Key:     "C",
+		Help:    "comment",
+		Applies: func(_ string, it ui.Item) bool { return actionable(it) },
+		Compose: func(it ui.Item) (string, error) {
+			i := issueOf(it)
+			c, err := client(i.host())
+			if err != nil {
+				return "", err
+			}
+			latest, err := gh.GetLatestComment(c, i.NodeID)
+			if err != nil || latest == nil {
+				return "", err
+			}
+			return latest.Quote(), nil
+		},
+		Run: func(it ui.Item, in ui.ActionInput) (ui.ActionResult, error) {
+			i := issueOf(it)
+			c, err := client(i.host())
+			if err != nil {
+				return ui.ActionResult{}, err
+			}
+			if err := gh.AddComment(c, i.NodeID, in.Text); err != nil {
+				return ui.ActionResult{}, err
+			}
+			return ui.ActionResult{Status: "Commented on " + i.reference()}, nil
+		},
+	}
+}
+
+// closeAction closes the selected or marked open issues as completed or not
+// planned.
+func closeAction(client gh.ClientFunc) ui.Action {
+	return ui.Action{
+		Key:  "X",
+		Help: "close",
+		Bulk: true,
+		Applies: func(_ string, it ui.Item) bool {
+			return actionable(it) && !issueOf(it).closed()
+		},
+		Choices: func(ui.Item) []string { return []string{"completed", "not planned"} },
+		Run: func(it ui.Item, in ui.ActionInput) (ui.ActionResult, error) {
+			i := issueOf(it)
+			c, err := client(i.host())
+			if err != nil {
+				return ui.ActionResult{}, err
+			}
+			if err := gh.CloseIssue(c, i.NodeID, closeReasons[in.Choice]); err != nil {
+				return ui.ActionResult{}, err
+			}
+			return ui.ActionResult{
+				Status: fmt.Sprintf("Closed %s as %s", i.reference(), in.Choice),
+				Remove: true,
+				Update: func(it ui.Item) ui.Item {
+					closed := issueOf(it)
+					closed.State = stateClosed
+					return it.WithData(closed)
+				},
+			}, nil
+		},
+	}
+}
+
+// reopenAction reopens the selected or marked closed issues, which tabs with
+// custom queries may list.
+func reopenAction(client gh.ClientFunc) ui.Action {
+	return ui.Action{
+		Key:  "O",
+		Help: "reopen",
+		Bulk: true,
+		Applies: func(_ string, it ui.Item) bool {
+			return actionable(it) && issueOf(it).closed()
+		},
+		Run: func(it ui.Item, _ ui.ActionInput) (ui.ActionResult, error) {
+			i := issueOf(it)
+			c, err := client(i.host())
+			if err != nil {
+				return ui.ActionResult{}, err
+			}
+			if err := gh.ReopenIssue(c, i.NodeID); err != nil {
+				return ui.ActionResult{}, err
+			}
+			return ui.ActionResult{
+				Status: "Reopened " + i.reference(),
+				Update: func(it ui.Item) ui.Item {
+					reopened := issueOf(it)
+					reopened.State = stateOpen
+					return it.WithData(reopened)
+				},
+			}, nil
+		},
+	}
+}
+
+// issueOf returns the issue it was built from.
+func issueOf(it ui.Item) issue {
+	if i, ok := it.Data().(issue); ok {
+		return i
+	}
+	return issue{}
+}
+
+// actionable reports whether it is an issue that can be acted on. Items from
+// a result cache written before node IDs were fetched cannot.
+func actionable(it ui.Item) bool {
+	i, ok := it.Data().(issue)
+	return ok && i.NodeID != ""
+}
+
+// closed reports whether the issue is closed.
+func (i issue) closed() bool {
+	return strings.EqualFold(i.State, stateClosed)
+}
+
+// reference returns the short form of the issue, e.g. "owner/repo#12".
+func (i issue) reference() string {
+	return fmt.Sprintf("%s#%d", i.repositoryDisplayName(), i.Number)
+}
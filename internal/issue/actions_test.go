@@ -0,0 +1,157 @@
+package issue
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/snrsw/gh-own/internal/ghtest"
+	"github.com/snrsw/gh-own/internal/ui"
+)
+
+func actionTestItem() ui.Item {
+	return issue{
+		NodeID:        "I_12",
+		Number:        12,
+		RepositoryURL: "https://api.github.com/repos/owner/repo",
+		HTMLURL:       "https://github.com/owner/repo/issues/12",
+		State:         "OPEN",
+	}.toItem("")
+}
+
+func TestActionsNotOfferedWithoutNodeID(t *testing.T) {
+	it := issue{Number: 12, RepositoryURL: "https://api.github.com/repos/owner/repo"}.toItem("")
+	for _, a := range Actions(ghtest.NewGitHub(t).Client) {
+		if a.Applies("created", it) {
+			t.Errorf("%s should not apply to an item without a node ID", a.Help)
+		}
+	}
+}
+
+func TestLabelAction(t *testing.T) {
+	fake := ghtest.NewGitHub(t,
+		`{"data":{"repository":{"labels":{"nodes":[{"id":"LA_1","name":"bug"},{"id":"LA_2","name":"triage"}]}}}}`,
+		`{"data":{"node":{"labels":{"nodes":[{"name":"triage"}]}}}}`,
+		`{"data":{"repository":{"labels":{"nodes":[{"id":"LA_1","name":"bug"},{"id":"LA_2","name":"triage"}]}}}}`,
+		`{"data":{}}`,
+		`{"data":{}}`,
+	)
+	action := ghtest.FindAction(t, Actions(fake.Client), "L")
+
+	options, err := action.Options(actionTestItem())
+	if err != nil {
+		t.Fatalf("Options() error: %v", err)
+	}
+	want := ui.Options{All: []string{"bug", "triage"}, Selected: []string{"triage"}}
+	if !reflect.DeepEqual(options, want) {
+		t.Errorf("Options() = %+v, want %+v", options, want)
+	}
+
+	result, err := action.Run(actionTestItem(), ui.ActionInput{Added: []string{"bug"}, Removed: []string{"triage"}})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if result.Status != "Updated labels of owner/repo#12" {
+		t.Errorf("Status = %q, want %q", result.Status, "Updated labels of owner/repo#12")
+	}
+	if !strings.Contains(fake.Requests[3].Query, "addLabelsToLabelable") || !strings.Contains(fake.Requests[4].Query, "removeLabelsFromLabelable") {
+		t.Errorf("mutations = %q, %q, want labels added then removed", fake.Requests[3].Query, fake.Requests[4].Query)
+	}
+}
+
+func TestAssignAction(t *testing.T) {
+	fake := ghtest.NewGitHub(t,
+		`{"data":{"repository":{"assignableUsers":{"nodes":[{"login":"alice"},{"login":"bob"}]}}}}`,
+		`{"data":{"node":{"assignees":{"nodes":[{"login":"bob"}]}}}}`,
+		`{"data":{"user":{"id":"U_1"}}}`,
+		`{"data":{}}`,
+	)
+	action := ghtest.FindAction(t, Actions(fake.Client), "A")
+
+	options, err := action.Options(actionTestItem())
+	if err != nil {
+		t.Fatalf("Options() error: %v", err)
+	}
+	want := ui.Options{All: []string{"alice", "bob"}, Selected: []string{"bob"}}
+	if !reflect.DeepEqual(options, want) {
+		t.Errorf("Options() = %+v, want %+v", options, want)
+	}
+
+	if _, err := action.Run(actionTestItem(), ui.ActionInput{Added: []string{"alice"}}); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if len(fake.Requests) != 4 || !strings.Contains(fake.Requests[3].Query, "addAssigneesToAssignable") {
+		t.Errorf("requests = %v, want a user lookup and addAssigneesToAssignable", fake.Requests)
+	}
+}
+
+func TestCloseAction(t *testing.T) {
+	fake := ghtest.NewGitHub(t, `{"data":{}}`)
+	action := ghtest.FindAction(t, Actions(fake.Client), "X")
+
+	if got := action.Choices(actionTestItem()); !reflect.DeepEqual(got, []string{"completed", "not planned"}) {
+		t.Errorf("Choices() = %v, want completed and not planned", got)
+	}
+	result, err := action.Run(actionTestItem(), ui.ActionInput{Choice: "not planned"})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if !result.Remove {
+		t.Error("a closed issue should be removed from the tabs")
+	}
+	if result.Update == nil || !issueOf(result.Update(actionTestItem())).closed() {
+		t.Error("a closed issue kept by a tab should be shown as closed")
+	}
+	if result.Status != "Closed owner/repo#12 as not planned" {
+		t.Errorf("Status = %q", result.Status)
+	}
+	if fake.Requests[0].Variables["reason"] != "NOT_PLANNED" {
+		t.Errorf("reason = %v, want NOT_PLANNED", fake.Requests[0].Variables["reason"])
+	}
+}
+
+func TestCloseAndReopenApplyByState(t *testing.T) {
+	actions := Actions(ghtest.NewGitHub(t).Client)
+	closeAction, reopenAction := ghtest.FindAction(t, actions, "X"), ghtest.FindAction(t, actions, "O")
+	closed := issue{NodeID: "I_12", Number: 12, State: "CLOSED", RepositoryURL: "https://api.github.com/repos/owner/repo"}.toItem("")
+
+	if !closeAction.Applies("created", actionTestItem()) || reopenAction.Applies("created", actionTestItem()) {
+		t.Error("an open issue should be offered close, not reopen")
+	}
+	if closeAction.Applies("created", closed) || !reopenAction.Applies("created", closed) {
+		t.Error("a closed issue should be offered reopen, not close")
+	}
+}
+
+func TestReopenAction(t *testing.T) {
+	fake := ghtest.NewGitHub(t, `{"data":{}}`)
+	action := ghtest.FindAction(t, Actions(fake.Client), "O")
+
+	closed := issue{NodeID: "I_12", Number: 12, State: "CLOSED", RepositoryURL: "https://api.github.com/repos/owner/repo"}.toItem("")
+	result, err := action.Run(closed, ui.ActionInput{})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if !strings.Contains(fake.Requests[0].Query, "reopenIssue") {
+		t.Errorf("query = %q, want reopenIssue", fake.Requests[0].Query)
+	}
+	if result.Update == nil || issueOf(result.Update(closed)).State != "OPEN" {
+		t.Error("Update should mark the issue as open, so it is offered close again")
+	}
+}
+
+func TestCommentAction_NoComments(t *testing.T) {
+	fake := ghtest.NewGitHub(t, `{"data":{"node":{"comments":{"nodes":[]}}}}`, `{"data":{}}`)
+	action := ghtest.FindAction(t, Actions(fake.Client), "C")
+
+	draft, err := action.Compose(actionTestItem())
+	if err != nil || draft != "" {
+		t.Errorf("Compose() = %q, %v, want an empty draft without comments", draft, err)
+	}
+	if _, err := action.Run(actionTestItem(), ui.ActionInput{Text: "Fixed in #13."}); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if !strings.Contains(fake.Requests[1].Query, "addComment") || fake.Requests[1].Variables["id"] != "I_12" {
+		t.Errorf("request = %+v, want addComment on I_12", fake.Requests[1])
+	}
+}
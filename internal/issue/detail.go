@@ -0,0 +1,31 @@
+// Package issue provides functionality to handle GitHub issues owned by a user.
+package issue
+
+import (
+	"fmt"
+
+	"github.com/snrsw/gh-own/internal/detail"
+	"github.com/snrsw/gh-own/internal/gh"
+	"github.com/snrsw/gh-own/internal/ui"
+)
+
+// Detail returns the function fetching the detail view of issue items.
+func Detail(client gh.ClientFunc) ui.DetailFunc {
+	return func(it ui.Item) (func(width int) string, error) {
+		i, ok := it.Data().(issue)
+		if !ok || i.NodeID == "" {
+			return nil, ui.ErrNotFetched
+		}
+		c, err := client(i.host())
+		if err != nil {
+			return nil, err
+		}
+		d, err := gh.GetIssueDetail(c, i.NodeID)
+		if err != nil {
+			return nil, err
+		}
+		title := fmt.Sprintf("%s #%d %s", i.repositoryDisplayName(), i.Number, i.Title)
+		login, _ := gh.CurrentLoginForHost(i.host())
+		return func(width int) string { return detail.Render(title, d, width, login) }, nil
+	}
+}
@@ -0,0 +1,97 @@
+// Package issue provides functionality to handle GitHub issues owned by a user.
+package issue
+
+import (
+	"fmt"
+
+	"github.com/snrsw/gh-own/internal/output"
+	"github.com/snrsw/gh-own/internal/ui"
+)
+
+// ExportFields lists the JSON fields available for issues.
+var ExportFields = []string{
+	"author",
+	"createdAt",
+	"host",
+	"latestActivity",
+	"number",
+	"repository",
+	"state",
+	"title",
+	"updatedAt",
+	"url",
+}
+
+// Export converts grouped issues into records grouped by tab, in the sort
+// configured for each tab.
+func (o *GroupedIssues) Export() []output.Group {
+	groups := o.tabGroups()
+	exported := make([]output.Group, 0, len(groups))
+	for _, g := range groups {
+		records := make([]output.Record, 0, len(g.result.Items))
+		for _, i := range sortIssues(g.result.Items, o.sort[g.key]) {
+			records = append(records, i.record())
+		}
+		exported = append(exported, output.Group{Key: g.key, Name: g.name, Records: records})
+	}
+	return exported
+}
+
+// Warnings describes the tabs whose search failed, in tab order.
+func (o *GroupedIssues) Warnings() []string {
+	var warnings []string
+	for _, g := range o.tabGroups() {
+		if g.err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %v", g.name, g.err))
+		}
+	}
+	return warnings
+}
+
+// Table converts grouped issues into a plain table with one row per issue, in
+// the sort configured for each tab.
+func (o *GroupedIssues) Table() output.Table {
+	t := output.Table{Headers: []string{"TAB", "REPO", "NUMBER", "TITLE", "LATEST ACTIVITY"}}
+	for _, g := range o.tabGroups() {
+		for _, i := range sortIssues(g.result.Items, o.sort[g.key]) {
+			t.Rows = append(t.Rows, i.row(g.key))
+		}
+	}
+	return t
+}
+
+func (i issue) row(tab string) []string {
+	return []string{
+		tab,
+		i.repositoryDisplayName(),
+		fmt.Sprintf("#%d", i.Number),
+		i.Title,
+		i.activitySummary(),
+	}
+}
+
+func (i issue) activitySummary() string {
+	if i.LatestActivity.Login == "" {
+		return "updated " + ui.UpdatedAgo(i.UpdatedAt)
+	}
+	return fmt.Sprintf("%s by @%s %s", i.LatestActivity.Kind, i.LatestActivity.Login, ui.UpdatedAgo(i.LatestActivity.At))
+}
+
+func (i issue) record() output.Record {
+	var activity any
+	if i.LatestActivity.Login != "" {
+		activity = i.LatestActivity
+	}
+	return output.Record{
+		"author":         i.User.Login,
+		"createdAt":      i.CreatedAt,
+		"host":           i.host(),
+		"latestActivity": activity,
+		"number":         i.Number,
+		"repository":     i.repositoryFullName(),
+		"state":          i.State,
+		"title":          i.Title,
+		"updatedAt":      i.UpdatedAt,
+		"url":            i.HTMLURL,
+	}
+}
@@ -0,0 +1,118 @@
+package issue
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/snrsw/gh-own/internal/gh"
+	"github.com/snrsw/gh-own/internal/output"
+)
+
+func TestExport_Issue_GroupsInTabOrder(t *testing.T) {
+	grouped := &GroupedIssues{
+		Assigned: gh.SearchResult[issue]{TotalCount: 1, Items: []issue{{Number: 1}}},
+		Custom: map[string]gh.SearchResult[issue]{
+			"bugs": {TotalCount: 0, Items: []issue{}},
+		},
+	}
+
+	groups := grouped.Export()
+
+	wantKeys := []string{"created", "participated", "assigned", "bugs"}
+	if len(groups) != len(wantKeys) {
+		t.Fatalf("Export() returned %d groups, want %d", len(groups), len(wantKeys))
+	}
+	for i, want := range wantKeys {
+		if groups[i].Key != want {
+			t.Errorf("groups[%d].Key = %q, want %q", i, groups[i].Key, want)
+		}
+	}
+	if len(groups[2].Records) != 1 {
+		t.Errorf("assigned has %d records, want 1", len(groups[2].Records))
+	}
+}
+
+func TestIssue_Record(t *testing.T) {
+	i := issue{
+		Number:         7,
+		Title:          "Bug",
+		State:          "OPEN",
+		HTMLURL:        "https://github.com/owner/repo/issues/7",
+		RepositoryURL:  "https://api.github.com/repos/owner/repo",
+		User:           gh.User{Login: "bob"},
+		LatestActivity: gh.LatestActivity{Kind: "commented", Login: "carol", At: "2024-03-10T12:00:00Z"},
+	}
+
+	r := i.record()
+
+	if r["repository"] != "owner/repo" {
+		t.Errorf("record[repository] = %v, want %q", r["repository"], "owner/repo")
+	}
+	if r["state"] != "OPEN" {
+		t.Errorf("record[state] = %v, want %q", r["state"], "OPEN")
+	}
+	activity, ok := r["latestActivity"].(gh.LatestActivity)
+	if !ok || activity.Login != "carol" {
+		t.Errorf("record[latestActivity] = %v, want activity by carol", r["latestActivity"])
+	}
+}
+
+func TestIssue_Record_HasAllExportFields(t *testing.T) {
+	r := issue{}.record()
+
+	fields := make([]string, 0, len(r))
+	for k := range r {
+		fields = append(fields, k)
+	}
+	if err := output.ValidateFields(fields, ExportFields); err != nil {
+		t.Errorf("record has fields missing from ExportFields: %v", err)
+	}
+	if len(r) != len(ExportFields) {
+		t.Errorf("record has %d fields, ExportFields has %d", len(r), len(ExportFields))
+	}
+}
+
+func TestWarnings_Issue_ListsFailedTabs(t *testing.T) {
+	grouped := NewGroupedIssues(&gh.IssueSearchResult{
+		Errors: map[string]error{"created": errors.New("timeout")},
+	}, "")
+
+	warnings := grouped.Warnings()
+	if len(warnings) != 1 || warnings[0] != "Created: timeout" {
+		t.Errorf("Warnings() = %v, want [Created: timeout]", warnings)
+	}
+	if got := NewGroupedIssues(&gh.IssueSearchResult{}, "").Warnings(); len(got) != 0 {
+		t.Errorf("Warnings() = %v, want none", got)
+	}
+}
+
+func TestTable_Issue_OneRowPerIssue(t *testing.T) {
+	grouped := &GroupedIssues{
+		Created: gh.SearchResult[issue]{TotalCount: 1, Items: []issue{
+			{
+				Number:         5,
+				Title:          "Crash on start",
+				RepositoryURL:  "https://api.github.com/repos/owner/repo",
+				LatestActivity: gh.LatestActivity{Kind: "commented", Login: "dave", At: "2020-01-01T00:00:00Z"},
+			},
+		}},
+		Assigned: gh.SearchResult[issue]{TotalCount: 1, Items: []issue{{Number: 6}}},
+	}
+
+	table := grouped.Table()
+
+	if len(table.Rows) != 2 {
+		t.Fatalf("Table() returned %d rows, want 2", len(table.Rows))
+	}
+	row := table.Rows[0]
+	if row[0] != "created" || row[1] != "owner/repo" || row[2] != "#5" || row[3] != "Crash on start" {
+		t.Errorf("row = %v, want [created owner/repo #5 Crash on start ...]", row)
+	}
+	if !strings.HasPrefix(row[4], "commented by @dave ") {
+		t.Errorf("row[4] = %q, want prefix %q", row[4], "commented by @dave ")
+	}
+	if table.Rows[1][0] != "assigned" {
+		t.Errorf("Rows[1][0] = %q, want %q", table.Rows[1][0], "assigned")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/snrsw/gh-own/internal/gh"
+	"github.com/snrsw/gh-own/internal/sortmode"
 )
 
 type GroupedIssues struct {
@@ -12,25 +13,33 @@ type GroupedIssues struct {
 	Assigned     gh.SearchResult[issue]
 	Participated gh.SearchResult[issue]
 	Custom       map[string]gh.SearchResult[issue]
+	RateLimit    gh.RateLimit
+	Errors       map[string]error
 	currentLogin string
+	hostLogins   map[string]string
+	sort         map[string]sortmode.SortMode
+	closedTabs   map[string]bool
 }
 
 func NewGroupedIssues(ghResult *gh.IssueSearchResult, currentLogin string) *GroupedIssues {
 	custom := make(map[string]gh.SearchResult[issue], len(ghResult.Custom))
 	for k, nodes := range ghResult.Custom {
-		custom[k] = toSearchResult(nodes)
+		custom[k] = toSearchResult(nodes, ghResult.Totals[k])
 	}
 
 	return &GroupedIssues{
-		Created:      toSearchResult(ghResult.Created),
-		Assigned:     toSearchResult(ghResult.Assigned),
-		Participated: toSearchResult(ghResult.Participated),
+		Created:      toSearchResult(ghResult.Created, ghResult.Totals["created"]),
+		Assigned:     toSearchResult(ghResult.Assigned, ghResult.Totals["assigned"]),
+		Participated: toSearchResult(ghResult.Participated, ghResult.Totals["participated"]),
 		Custom:       custom,
+		RateLimit:    ghResult.RateLimit,
+		Errors:       ghResult.Errors,
 		currentLogin: currentLogin,
 	}
 }
 
 type issue struct {
+	NodeID         string            `json:"node_id"`
 	Number         int               `json:"number"`
 	User           gh.User           `json:"user"`
 	RepositoryURL  string            `json:"repository_url"`
@@ -42,6 +51,49 @@ type issue struct {
 	LatestActivity gh.LatestActivity `json:"-"`
 }
 
+// WithHostLogins sets the login used for items on each host, overriding the
+// current login for hosts other than the default one.
+func (o *GroupedIssues) WithHostLogins(logins map[string]string) *GroupedIssues {
+	o.hostLogins = logins
+	return o
+}
+
+// WithSort sets the sort mode of each tab, keyed like the queries in the
+// config. Tabs without one keep the order the search returned.
+func (o *GroupedIssues) WithSort(sort map[string]sortmode.SortMode) *GroupedIssues {
+	o.sort = sort
+	return o
+}
+
+// WithClosedTabs sets the keys of the tabs whose queries may match closed
+// items, which keep items closed by an action.
+func (o *GroupedIssues) WithClosedTabs(keys map[string]bool) *GroupedIssues {
+	o.closedTabs = keys
+	return o
+}
+
+// loginFor returns the current user's login on host.
+func (o *GroupedIssues) loginFor(host string) string {
+	if login, ok := o.hostLogins[host]; ok {
+		return login
+	}
+	return o.currentLogin
+}
+
+// host returns the hostname the issue lives on.
+func (i *issue) host() string {
+	return gh.HostFromURL(i.HTMLURL)
+}
+
+// repositoryDisplayName returns the repository name, prefixed with the host
+// for issues outside github.com.
+func (i *issue) repositoryDisplayName() string {
+	if h := i.host(); h != gh.DefaultHostname {
+		return h + "/" + i.repositoryFullName()
+	}
+	return i.repositoryFullName()
+}
+
 func (i *issue) repositoryFullName() string {
 	// Format: "https://api.github.com/repos/owner/repo"
 	parts := strings.Split(i.RepositoryURL, "/")
@@ -51,10 +103,12 @@ func (i *issue) repositoryFullName() string {
 	return parts[len(parts)-2] + "/" + parts[len(parts)-1]
 }
 
-func toSearchResult(nodes []gh.IssueSearchNode) gh.SearchResult[issue] {
+// toSearchResult converts nodes into a search result. total is the number of
+// matches reported by GitHub; it never reduces TotalCount below len(nodes).
+func toSearchResult(nodes []gh.IssueSearchNode, total int) gh.SearchResult[issue] {
 	issues := fromGraphQLNodes(nodes)
 	return gh.SearchResult[issue]{
-		TotalCount: len(issues),
+		TotalCount: max(total, len(issues)),
 		Items:      issues,
 	}
 }
@@ -69,6 +123,7 @@ func fromGraphQLNodes(nodes []gh.IssueSearchNode) []issue {
 
 func fromGraphQL(node gh.IssueSearchNode) issue {
 	return issue{
+		NodeID:         node.ID,
 		Number:         node.Number,
 		User:           gh.User{Login: node.Author.Login},
 		RepositoryURL:  node.RepositoryURL(),
@@ -1,6 +1,7 @@
 package issue
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -29,6 +30,23 @@ func TestNewGroupedIssues_PropagatesCustom(t *testing.T) {
 	}
 }
 
+func TestNewGroupedIssues_UsesTotals(t *testing.T) {
+	ghResult := &gh.IssueSearchResult{
+		Assigned: []gh.IssueSearchNode{{Number: 1}},
+		Totals:   map[string]int{"assigned": 80},
+	}
+
+	grouped := NewGroupedIssues(ghResult, "")
+
+	if grouped.Assigned.TotalCount != 80 {
+		t.Errorf("Assigned.TotalCount = %d, want 80", grouped.Assigned.TotalCount)
+	}
+	tabs := grouped.BuildTabs()
+	if tabs[2].Name() != "Assigned (1 of 80)" {
+		t.Errorf("tabs[2].Name() = %q, want %q", tabs[2].Name(), "Assigned (1 of 80)")
+	}
+}
+
 func TestBuildTabs_Issue_DefaultTabsOnly(t *testing.T) {
 	grouped := &GroupedIssues{
 		Created:      gh.SearchResult[issue]{TotalCount: 1, Items: []issue{{Number: 1}}},
@@ -67,6 +85,45 @@ func TestBuildTabs_Issue_WithCustomTabs(t *testing.T) {
 	}
 }
 
+func TestBuildTabs_Issue_ErrorTab(t *testing.T) {
+	grouped := &GroupedIssues{
+		Created:      gh.SearchResult[issue]{TotalCount: 1, Items: []issue{{Number: 1}}},
+		Participated: gh.SearchResult[issue]{TotalCount: 0, Items: []issue{}},
+		Assigned:     gh.SearchResult[issue]{TotalCount: 0, Items: []issue{}},
+		Errors:       map[string]error{"assigned": errors.New("invalid query")},
+	}
+
+	tabs := grouped.BuildTabs()
+
+	if got, want := tabs[2].Name(), "Assigned (error: invalid query)"; got != want {
+		t.Errorf("tabs[2].Name() = %q, want %q", got, want)
+	}
+	if tabs[2].Err() == nil {
+		t.Error("tabs[2].Err() = nil, want the search error")
+	}
+	if tabs[0].Err() != nil {
+		t.Errorf("tabs[0].Err() = %v, want nil", tabs[0].Err())
+	}
+}
+
+func TestBuildTabs_Issue_FailedCustomSearchGetsTab(t *testing.T) {
+	grouped := &GroupedIssues{
+		Errors: map[string]error{"needsTriage": errors.New("invalid query")},
+	}
+
+	tabs := grouped.BuildTabs()
+
+	if len(tabs) != 4 {
+		t.Fatalf("BuildTabs() returned %d tabs, want the failed custom tab after the default ones", len(tabs))
+	}
+	if got, want := tabs[3].Name(), "NeedsTriage (error: invalid query)"; got != want {
+		t.Errorf("tabs[3].Name() = %q, want %q", got, want)
+	}
+	if warnings := grouped.Warnings(); len(warnings) != 1 || warnings[0] != "NeedsTriage: invalid query" {
+		t.Errorf("Warnings() = %v, want the failed custom tab", warnings)
+	}
+}
+
 func TestIssue_RepositoryFullName(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -123,6 +180,49 @@ func TestIssue_RepositoryFullName(t *testing.T) {
 	}
 }
 
+func TestIssue_RepositoryDisplayName(t *testing.T) {
+	tests := []struct {
+		name    string
+		htmlURL string
+		want    string
+	}{
+		{
+			name:    "github.com omits host",
+			htmlURL: "https://github.com/owner/repo/issues/1",
+			want:    "owner/repo",
+		},
+		{
+			name:    "enterprise host is prefixed",
+			htmlURL: "https://ghe.example.com/owner/repo/issues/1",
+			want:    "ghe.example.com/owner/repo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &issue{
+				RepositoryURL: "https://api.github.com/repos/owner/repo",
+				HTMLURL:       tt.htmlURL,
+			}
+			if got := i.repositoryDisplayName(); got != tt.want {
+				t.Errorf("repositoryDisplayName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupedIssues_LoginFor(t *testing.T) {
+	o := &GroupedIssues{currentLogin: "me"}
+	o = o.WithHostLogins(map[string]string{"ghe.example.com": "corp-me"})
+
+	if got := o.loginFor("ghe.example.com"); got != "corp-me" {
+		t.Errorf("loginFor(ghe.example.com) = %q, want %q", got, "corp-me")
+	}
+	if got := o.loginFor("github.com"); got != "me" {
+		t.Errorf("loginFor(github.com) = %q, want %q", got, "me")
+	}
+}
+
 func TestIssue_ToItem_NoActivity(t *testing.T) {
 	i := issue{
 		Number:        7,
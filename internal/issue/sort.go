@@ -0,0 +1,63 @@
+package issue
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/snrsw/gh-own/internal/sortmode"
+	"github.com/snrsw/gh-own/internal/ui"
+)
+
+// activityAt returns when the latest comment happened, falling back to the
+// last update.
+func (i issue) activityAt() string {
+	if i.LatestActivity.At != "" {
+		return i.LatestActivity.At
+	}
+	return i.UpdatedAt
+}
+
+// compareIssues returns the comparison of mode. Times and numbers sort newest
+// first and repositories alphabetically.
+func compareIssues(mode sortmode.SortMode) func(a, b issue) int {
+	switch mode {
+	case sortmode.SortModeUpdated:
+		return func(a, b issue) int { return cmp.Compare(b.UpdatedAt, a.UpdatedAt) }
+	case sortmode.SortModeCreated:
+		return func(a, b issue) int { return cmp.Compare(b.CreatedAt, a.CreatedAt) }
+	case sortmode.SortModeActivity:
+		return func(a, b issue) int { return cmp.Compare(b.activityAt(), a.activityAt()) }
+	case sortmode.SortModeRepo:
+		return func(a, b issue) int {
+			return cmp.Or(cmp.Compare(a.repositoryDisplayName(), b.repositoryDisplayName()), cmp.Compare(b.Number, a.Number))
+		}
+	case sortmode.SortModeNumber:
+		return func(a, b issue) int { return cmp.Compare(b.Number, a.Number) }
+	default:
+		return func(issue, issue) int { return 0 }
+	}
+}
+
+// sortIssues returns a copy of issues sorted by mode, keeping the search order
+// among equal issues.
+func sortIssues(issues []issue, mode sortmode.SortMode) []issue {
+	if mode == sortmode.SortModeNone {
+		return issues
+	}
+	sorted := slices.Clone(issues)
+	slices.SortStableFunc(sorted, compareIssues(mode))
+	return sorted
+}
+
+// tabSorts returns the sorts an issue tab can be cycled through with "s".
+func tabSorts() []ui.Sort {
+	sorts := make([]ui.Sort, len(sortmode.IssueModes))
+	for i, mode := range sortmode.IssueModes {
+		compare := compareIssues(mode)
+		sorts[i] = ui.Sort{
+			Name:    string(mode),
+			Compare: func(a, b ui.Item) int { return compare(issueOf(a), issueOf(b)) },
+		}
+	}
+	return sorts
+}
@@ -0,0 +1,84 @@
+package issue
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/snrsw/gh-own/internal/gh"
+	"github.com/snrsw/gh-own/internal/sortmode"
+)
+
+func TestSortIssues(t *testing.T) {
+	issues := []issue{
+		{
+			Number:         1,
+			RepositoryURL:  "https://api.github.com/repos/owner/b",
+			UpdatedAt:      "2024-01-03T00:00:00Z",
+			CreatedAt:      "2024-01-01T00:00:00Z",
+			LatestActivity: gh.LatestActivity{At: "2024-01-02T00:00:00Z"},
+		},
+		{
+			Number:        3,
+			RepositoryURL: "https://api.github.com/repos/owner/a",
+			UpdatedAt:     "2024-01-01T00:00:00Z",
+			CreatedAt:     "2024-01-02T00:00:00Z",
+		},
+		{
+			Number:         2,
+			RepositoryURL:  "https://api.github.com/repos/owner/b",
+			UpdatedAt:      "2024-01-02T00:00:00Z",
+			CreatedAt:      "2024-01-03T00:00:00Z",
+			LatestActivity: gh.LatestActivity{At: "2024-01-04T00:00:00Z"},
+		},
+	}
+
+	tests := []struct {
+		mode sortmode.SortMode
+		want []int
+	}{
+		{sortmode.SortModeNone, []int{1, 3, 2}},
+		{sortmode.SortModeUpdated, []int{1, 2, 3}},
+		{sortmode.SortModeCreated, []int{2, 3, 1}},
+		{sortmode.SortModeActivity, []int{2, 1, 3}},
+		{sortmode.SortModeRepo, []int{3, 2, 1}},
+		{sortmode.SortModeNumber, []int{3, 2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			sorted := sortIssues(issues, tt.mode)
+			got := make([]int, len(sorted))
+			for i, is := range sorted {
+				got[i] = is.Number
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("sortIssues(%q) = %v, want %v", tt.mode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildTabs_AppliesSort(t *testing.T) {
+	grouped := (&GroupedIssues{
+		Created: gh.SearchResult[issue]{TotalCount: 1, Items: []issue{{Number: 1}}},
+	}).WithSort(map[string]sortmode.SortMode{"created": sortmode.SortModeUpdated})
+
+	tabs := grouped.BuildTabs()
+
+	if got := tabs[0].SortName(); got != "updated" {
+		t.Errorf("tabs[0].SortName() = %q, want %q", got, "updated")
+	}
+}
+
+func TestExport_AppliesSort(t *testing.T) {
+	grouped := (&GroupedIssues{
+		Created: gh.SearchResult[issue]{TotalCount: 2, Items: []issue{
+			{Number: 1, CreatedAt: "2024-01-01T00:00:00Z"},
+			{Number: 2, CreatedAt: "2024-02-01T00:00:00Z"},
+		}},
+	}).WithSort(map[string]sortmode.SortMode{"created": sortmode.SortModeCreated})
+
+	records := grouped.Export()[0].Records
+	if len(records) != 2 || records[0]["number"] != 2 {
+		t.Errorf("Export() records = %v, want the configured sort", records)
+	}
+}
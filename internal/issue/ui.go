@@ -3,6 +3,7 @@ package issue
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 
 	"github.com/charmbracelet/bubbles/list"
@@ -12,24 +13,62 @@ import (
 
 // BuildTabs converts grouped issues into UI tabs.
 func (o *GroupedIssues) BuildTabs() []ui.Tab {
-	tabs := []ui.Tab{
-		ui.NewTab(fmt.Sprintf("Created (%d)", o.Created.TotalCount), ui.CreateList(o.issueItems(o.Created))),
-		ui.NewTab(fmt.Sprintf("Participated (%d)", o.Participated.TotalCount), ui.CreateList(o.issueItems(o.Participated))),
-		ui.NewTab(fmt.Sprintf("Assigned (%d)", o.Assigned.TotalCount), ui.CreateList(o.issueItems(o.Assigned))),
+	groups := o.tabGroups()
+	tabs := make([]ui.Tab, 0, len(groups))
+	for _, g := range groups {
+		mode := o.sort[g.key]
+		tab := ui.NewTab(g.name, ui.CreateList(o.issueItems(g.result))).WithKey(g.key).WithError(g.err).WithClosed(o.closedTabs[g.key])
+		tabs = append(tabs, tab.WithTitle(g.result.TotalCount, g.title).WithSorts(tabSorts(), string(mode)))
+	}
+	return tabs
+}
+
+type tabGroup struct {
+	key    string
+	name   string
+	result gh.SearchResult[issue]
+	err    error
+}
+
+// title returns the tab title for shown of total matches.
+func (g tabGroup) title(shown, total int) string {
+	if g.err != nil {
+		return ui.ErrorTabTitle(g.name, shown, gh.ErrorSummary(g.err))
+	}
+	return ui.TabTitle(g.name, shown, total)
+}
+
+// tabGroups returns the default groups followed by custom groups sorted by key.
+func (o *GroupedIssues) tabGroups() []tabGroup {
+	groups := []tabGroup{
+		{key: "created", name: "Created", result: o.Created},
+		{key: "participated", name: "Participated", result: o.Participated},
+		{key: "assigned", name: "Assigned", result: o.Assigned},
 	}
 
 	keys := make([]string, 0, len(o.Custom))
 	for k := range o.Custom {
 		keys = append(keys, k)
 	}
+	// A custom search that failed has no results, but still gets its tab to
+	// show the error.
+	for k := range o.Errors {
+		_, ok := o.Custom[k]
+		if !ok && !slices.ContainsFunc(groups, func(g tabGroup) bool { return g.key == k }) {
+			keys = append(keys, k)
+		}
+	}
 	sort.Strings(keys)
 
 	for _, k := range keys {
-		sr := o.Custom[k]
-		tabs = append(tabs, ui.NewTab(fmt.Sprintf("%s (%d)", ui.HumanizeTabName(k), sr.TotalCount), ui.CreateList(o.issueItems(sr))))
+		groups = append(groups, tabGroup{key: k, name: ui.HumanizeTabName(k), result: o.Custom[k]})
 	}
 
-	return tabs
+	for i := range groups {
+		groups[i].err = o.Errors[groups[i].key]
+	}
+
+	return groups
 }
 
 func (i issue) toItem(currentLogin string) ui.Item {
@@ -52,17 +91,17 @@ func (i issue) toItem(currentLogin string) ui.Item {
 		)
 	}
 	return ui.NewItem(
-		i.repositoryFullName(),
+		i.repositoryDisplayName(),
 		fmt.Sprintf("#%d %s", i.Number, i.Title),
 		desc,
 		i.HTMLURL,
-	)
+	).WithData(i)
 }
 
 func (o *GroupedIssues) issueItems(issues gh.SearchResult[issue]) []list.Item {
 	items := make([]list.Item, 0, len(issues.Items))
 	for _, issue := range issues.Items {
-		items = append(items, issue.toItem(o.currentLogin))
+		items = append(items, issue.toItem(o.loginFor(issue.host())))
 	}
 	return items
 }
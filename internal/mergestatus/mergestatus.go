@@ -0,0 +1,61 @@
+package mergestatus
+
+import "github.com/charmbracelet/lipgloss"
+
+type MergeStatus int
+
+const (
+	MergeStatusUnknown MergeStatus = iota
+	MergeStatusClean
+	// MergeStatusBehind marks a branch that must be updated with its base
+	// branch before it can be merged.
+	MergeStatusBehind
+	MergeStatusConflicting
+)
+
+func (s MergeStatus) String() string {
+	switch s {
+	case MergeStatusClean:
+		return "clean"
+	case MergeStatusBehind:
+		return "behind"
+	case MergeStatusConflicting:
+		return "conflicting"
+	default:
+		return "unknown"
+	}
+}
+
+// Parse combines the mergeable and mergeStateStatus fields of a pull request.
+// GitHub reports BEHIND only when the base branch requires branches to be up
+// to date before merging.
+func Parse(mergeable, mergeStateStatus string) MergeStatus {
+	switch {
+	case mergeable == "CONFLICTING" || mergeStateStatus == "DIRTY":
+		return MergeStatusConflicting
+	case mergeStateStatus == "BEHIND":
+		return MergeStatusBehind
+	case mergeable == "MERGEABLE":
+		return MergeStatusClean
+	default:
+		return MergeStatusUnknown
+	}
+}
+
+var (
+	conflictingStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#CF222E"))
+	behindStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("#9A6700"))
+)
+
+// RenderMergeStatus renders a glyph for the statuses that need attention and
+// nothing otherwise.
+func RenderMergeStatus(status MergeStatus) string {
+	switch status {
+	case MergeStatusConflicting:
+		return conflictingStyle.Render("≠")
+	case MergeStatusBehind:
+		return behindStyle.Render("↓")
+	default:
+		return ""
+	}
+}
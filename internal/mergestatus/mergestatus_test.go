@@ -0,0 +1,76 @@
+package mergestatus
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergeStatus_String(t *testing.T) {
+	tests := []struct {
+		status MergeStatus
+		want   string
+	}{
+		{MergeStatusClean, "clean"},
+		{MergeStatusBehind, "behind"},
+		{MergeStatusConflicting, "conflicting"},
+		{MergeStatusUnknown, "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.status.String(); got != tt.want {
+				t.Errorf("MergeStatus.String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		mergeable, mergeStateStatus string
+		want                        MergeStatus
+	}{
+		{"MERGEABLE", "CLEAN", MergeStatusClean},
+		{"MERGEABLE", "BLOCKED", MergeStatusClean},
+		{"MERGEABLE", "BEHIND", MergeStatusBehind},
+		{"CONFLICTING", "DIRTY", MergeStatusConflicting},
+		{"UNKNOWN", "DIRTY", MergeStatusConflicting},
+		{"UNKNOWN", "UNKNOWN", MergeStatusUnknown},
+		{"", "", MergeStatusUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mergeable+"/"+tt.mergeStateStatus, func(t *testing.T) {
+			if got := Parse(tt.mergeable, tt.mergeStateStatus); got != tt.want {
+				t.Errorf("Parse(%q, %q) = %v, want %v", tt.mergeable, tt.mergeStateStatus, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderMergeStatus(t *testing.T) {
+	tests := []struct {
+		status   MergeStatus
+		contains string
+	}{
+		{MergeStatusConflicting, "≠"},
+		{MergeStatusBehind, "↓"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			got := RenderMergeStatus(tt.status)
+			if !strings.Contains(got, tt.contains) {
+				t.Errorf("RenderMergeStatus(%v) = %q, should contain %q", tt.status, got, tt.contains)
+			}
+		})
+	}
+}
+
+func TestRenderMergeStatus_NothingToFlag(t *testing.T) {
+	for _, s := range []MergeStatus{MergeStatusClean, MergeStatusUnknown} {
+		if got := RenderMergeStatus(s); got != "" {
+			t.Errorf("RenderMergeStatus(%v) = %q, want empty", s, got)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Package output renders fetched results for non-interactive consumers.
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/jq"
+	"github.com/cli/go-gh/v2/pkg/template"
+)
+
+// Record is a single exported item keyed by JSON field name.
+type Record map[string]any
+
+// Group holds the records of one tab, identified by its query key.
+type Group struct {
+	Key     string
+	Name    string
+	Records []Record
+}
+
+// ValidateFields returns an error if any of fields is not in available.
+func ValidateFields(fields, available []string) error {
+	known := make(map[string]bool, len(available))
+	for _, f := range available {
+		known[f] = true
+	}
+	for _, f := range fields {
+		if !known[f] {
+			return fmt.Errorf("unknown JSON field: %q\nAvailable fields:\n  %s", f, strings.Join(available, "\n  "))
+		}
+	}
+	return nil
+}
+
+// Options controls how groups are written.
+type Options struct {
+	// Fields restricts each record to the listed fields; empty keeps all fields.
+	Fields []string
+	// JQ is a jq expression applied to the JSON output.
+	JQ string
+	// Template is a Go template applied to the JSON output.
+	Template string
+	// Width is the terminal width used by template table helpers.
+	Width int
+	// Color enables the template color helpers.
+	Color bool
+}
+
+// Write writes groups as a JSON object keyed by group key, optionally filtered
+// through a jq expression or rendered with a Go template.
+func Write(w io.Writer, groups []Group, opts Options) error {
+	data, err := MarshalGroups(groups, opts.Fields)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case opts.JQ != "":
+		return jq.Evaluate(bytes.NewReader(data), w, opts.JQ)
+	case opts.Template != "":
+		return writeTemplate(w, data, opts)
+	default:
+		return writeIndented(w, data)
+	}
+}
+
+func writeTemplate(w io.Writer, data []byte, opts Options) error {
+	t := template.New(w, opts.Width, opts.Color)
+	if err := t.Parse(opts.Template); err != nil {
+		return err
+	}
+	if err := t.Execute(bytes.NewReader(data)); err != nil {
+		return err
+	}
+	return t.Flush()
+}
+
+func writeIndented(w io.Writer, data []byte) error {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// MarshalGroups encodes groups as a compact JSON object keyed by group key, preserving group order.
+func MarshalGroups(groups []Group, fields []string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, g := range groups {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(g.Key)
+		if err != nil {
+			return nil, err
+		}
+		records, err := json.Marshal(selectFields(g.Records, fields))
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(records)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func selectFields(records []Record, fields []string) []Record {
+	selected := make([]Record, 0, len(records))
+	for _, r := range records {
+		if len(fields) == 0 {
+			selected = append(selected, r)
+			continue
+		}
+		s := make(Record, len(fields))
+		for _, f := range fields {
+			s[f] = r[f]
+		}
+		selected = append(selected, s)
+	}
+	return selected
+}
@@ -0,0 +1,127 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestValidateFields_Known(t *testing.T) {
+	if err := ValidateFields([]string{"number", "title"}, []string{"number", "title", "url"}); err != nil {
+		t.Errorf("ValidateFields() error: %v", err)
+	}
+}
+
+func TestValidateFields_Unknown(t *testing.T) {
+	err := ValidateFields([]string{"number", "nope"}, []string{"number", "title"})
+	if err == nil {
+		t.Fatal("expected error for unknown field, got nil")
+	}
+	if !strings.Contains(err.Error(), `"nope"`) {
+		t.Errorf("error = %q, want it to name the unknown field", err.Error())
+	}
+}
+
+func TestMarshalGroups_PreservesGroupOrder(t *testing.T) {
+	groups := []Group{
+		{Key: "zeta", Records: []Record{{"number": 1}}},
+		{Key: "alpha", Records: []Record{}},
+	}
+
+	data, err := MarshalGroups(groups, nil)
+	if err != nil {
+		t.Fatalf("MarshalGroups() error: %v", err)
+	}
+
+	want := `{"zeta":[{"number":1}],"alpha":[]}`
+	if string(data) != want {
+		t.Errorf("MarshalGroups() = %s, want %s", data, want)
+	}
+}
+
+func TestMarshalGroups_SelectsFields(t *testing.T) {
+	groups := []Group{
+		{Key: "created", Records: []Record{{"number": 1, "title": "PR", "url": "u"}}},
+	}
+
+	data, err := MarshalGroups(groups, []string{"number", "url"})
+	if err != nil {
+		t.Fatalf("MarshalGroups() error: %v", err)
+	}
+
+	var got map[string][]map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("failed to unmarshal output: %v", err)
+	}
+	rec := got["created"][0]
+	if len(rec) != 2 {
+		t.Errorf("record has %d fields, want 2: %v", len(rec), rec)
+	}
+	if _, ok := rec["title"]; ok {
+		t.Error("record should not contain unselected field \"title\"")
+	}
+}
+
+func TestWrite_JSONIsIndented(t *testing.T) {
+	var buf bytes.Buffer
+	groups := []Group{{Key: "created", Records: []Record{{"number": 1}}}}
+
+	if err := Write(&buf, groups, Options{}); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "\n  \"created\"") {
+		t.Errorf("Write() output is not indented: %q", buf.String())
+	}
+	if !strings.HasSuffix(buf.String(), "\n") {
+		t.Error("Write() output should end with a newline")
+	}
+}
+
+func TestWrite_JQ(t *testing.T) {
+	var buf bytes.Buffer
+	groups := []Group{
+		{Key: "created", Records: []Record{{"number": 1, "title": "first"}, {"number": 2, "title": "second"}}},
+	}
+
+	if err := Write(&buf, groups, Options{JQ: ".created[] | .title"}); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	if got, want := buf.String(), "first\nsecond\n"; got != want {
+		t.Errorf("Write() = %q, want %q", got, want)
+	}
+}
+
+func TestWrite_JQ_InvalidExpression(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := Write(&buf, nil, Options{JQ: ".["}); err == nil {
+		t.Fatal("expected error for invalid jq expression, got nil")
+	}
+}
+
+func TestWrite_Template(t *testing.T) {
+	var buf bytes.Buffer
+	groups := []Group{
+		{Key: "created", Records: []Record{{"number": 1, "repository": "owner/repo"}}},
+	}
+	tmpl := `{{range .created}}{{.repository}}#{{.number}}{{"\n"}}{{end}}`
+
+	if err := Write(&buf, groups, Options{Template: tmpl, Width: 80}); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	if got, want := buf.String(), "owner/repo#1\n"; got != want {
+		t.Errorf("Write() = %q, want %q", got, want)
+	}
+}
+
+func TestWrite_Template_InvalidTemplate(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := Write(&buf, nil, Options{Template: "{{"}); err == nil {
+		t.Fatal("expected error for invalid template, got nil")
+	}
+}
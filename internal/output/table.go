@@ -0,0 +1,36 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Table is plain-text tabular output with one row per item.
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// WriteTable writes t to w as column-aligned plain text.
+func WriteTable(w io.Writer, t Table) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, joinCells(t.Headers)); err != nil {
+		return err
+	}
+	for _, row := range t.Rows {
+		if _, err := fmt.Fprintln(tw, joinCells(row)); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+func joinCells(cells []string) string {
+	cleaned := make([]string, len(cells))
+	for i, c := range cells {
+		cleaned[i] = strings.NewReplacer("\t", " ", "\n", " ").Replace(c)
+	}
+	return strings.Join(cleaned, "\t")
+}
@@ -0,0 +1,49 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteTable_AlignsColumns(t *testing.T) {
+	var buf bytes.Buffer
+	table := Table{
+		Headers: []string{"REPO", "NUMBER"},
+		Rows: [][]string{
+			{"owner/repo", "#1"},
+			{"o/r", "#22"},
+		},
+	}
+
+	if err := WriteTable(&buf, table); err != nil {
+		t.Fatalf("WriteTable() error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("WriteTable() wrote %d lines, want 3", len(lines))
+	}
+	col := strings.Index(lines[0], "NUMBER")
+	for i, line := range lines[1:] {
+		if strings.Index(line, "#") != col {
+			t.Errorf("row %d: second column starts at %d, want %d: %q", i, strings.Index(line, "#"), col, line)
+		}
+	}
+}
+
+func TestWriteTable_ReplacesTabsAndNewlines(t *testing.T) {
+	var buf bytes.Buffer
+	table := Table{
+		Headers: []string{"TITLE"},
+		Rows:    [][]string{{"multi\tpart\ntitle"}},
+	}
+
+	if err := WriteTable(&buf, table); err != nil {
+		t.Fatalf("WriteTable() error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "multi part title") {
+		t.Errorf("WriteTable() = %q, want tabs and newlines replaced by spaces", buf.String())
+	}
+}
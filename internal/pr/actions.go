@@ -0,0 +1,384 @@
+// Package pr provides functionality to handle GitHub pull requests owned by a user.
+package pr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/snrsw/gh-own/internal/cistatus"
+	"github.com/snrsw/gh-own/internal/gh"
+	"github.com/snrsw/gh-own/internal/mergestatus"
+	"github.com/snrsw/gh-own/internal/ui"
+)
+
+// Actions returns the actions offered on pull request items.
+func Actions(client gh.ClientFunc) []ui.Action {
+	return []ui.Action{
+		reviewAction(client),
+		mergeAction(client),
+		failedLogAction(client),
+		rerunAction(client),
+		updateBranchAction(client),
+		labelAction(client),
+		draftAction(client, false),
+		draftAction(client, true),
+		commentAction(client),
+		closeAction(client),
+	}
+}
+
+var reviewEvents = map[string]gh.ReviewEvent{
+	"approve":         gh.ReviewApprove,
+	"request changes": gh.ReviewRequestChanges,
+	"comment":         gh.ReviewComment,
+}
+
+// reviewAction submits a review on the selected pull request.
+func reviewAction(client gh.ClientFunc) ui.Action {
+	return ui.Action{
+		Key:     "R",
+		Help:    "review",
+		Applies: func(_ string, it ui.Item) bool { return actionable(it) },
+		Choices: func(ui.Item) []string { return []string{"approve", "request changes", "comment"} },
+		Prompt: func(_ ui.Item, choice string) ui.Prompt {
+			if choice == "approve" {
+				return ui.Prompt{Label: "Comment (optional)"}
+			}
+			return ui.Prompt{Label: "Comment", Required: true}
+		},
+		Run: func(it ui.Item, in ui.ActionInput) (ui.ActionResult, error) {
+			p := pullRequestOf(it)
+			c, err := client(p.host())
+			if err != nil {
+				return ui.ActionResult{}, err
+			}
+			event := reviewEvents[in.Choice]
+			if err := gh.AddPullRequestReview(c, p.NodeID, event, in.Text); err != nil {
+				return ui.ActionResult{}, err
+			}
+			return ui.ActionResult{Status: reviewStatus(event) + " " + p.reference()}, nil
+		},
+	}
+}
+
+func reviewStatus(event gh.ReviewEvent) string {
+	switch event {
+	case gh.ReviewApprove:
+		return "Approved"
+	case gh.ReviewRequestChanges:
+		return "Requested changes on"
+	default:
+		return "Commented on"
+	}
+}
+
+var mergeMethods = map[string]gh.MergeMethod{
+	"merge":  gh.MergeMethodMerge,
+	"squash": gh.MergeMethodSquash,
+	"rebase": gh.MergeMethodRebase,
+}
+
+var errConflicts = errors.New("the branch has conflicts with the base branch")
+
+// autoMergePrefix marks the choices that enable auto-merge instead of merging.
+const autoMergePrefix = "auto-merge: "
+
+// mergeAction merges the selected pull request of the Created tab, or enables
+// auto-merge while its checks are pending.
+func mergeAction(client gh.ClientFunc) ui.Action {
+	return ui.Action{
+		Key:  "m",
+		Help: "merge",
+		Applies: func(tab string, it ui.Item) bool {
+			return tab == "created" && actionable(it) && !pullRequestOf(it).Draft
+		},
+		Choices: func(it ui.Item) []string {
+			methods := []string{"squash", "merge", "rebase"}
+			if pullRequestOf(it).CIStatus != cistatus.CIStatusPending {
+				return methods
+			}
+			choices := make([]string, 0, 2*len(methods))
+			for _, m := range methods {
+				choices = append(choices, autoMergePrefix+m)
+			}
+			return append(choices, methods...)
+		},
+		Run: func(it ui.Item, in ui.ActionInput) (ui.ActionResult, error) {
+			p := pullRequestOf(it)
+			c, err := client(p.host())
+			if err != nil {
+				return ui.ActionResult{}, err
+			}
+			mb, err := gh.GetMergeability(c, p.NodeID)
+			if err != nil {
+				return ui.ActionResult{}, err
+			}
+
+			if method, ok := strings.CutPrefix(in.Choice, autoMergePrefix); ok {
+				if mb.Mergeable == "CONFLICTING" {
+					return ui.ActionResult{}, errConflicts
+				}
+				if err := gh.EnableAutoMerge(c, p.NodeID, mergeMethods[method]); err != nil {
+					return ui.ActionResult{}, err
+				}
+				return ui.ActionResult{Status: "Auto-merge enabled for " + p.reference()}, nil
+			}
+
+			if err := mergeBlocker(mb); err != nil {
+				return ui.ActionResult{}, err
+			}
+			if err := gh.MergePullRequest(c, p.NodeID, mergeMethods[in.Choice]); err != nil {
+				return ui.ActionResult{}, err
+			}
+			return ui.ActionResult{Status: "Merged " + p.reference(), Remove: true}, nil
+		},
+	}
+}
+
+var errNoFailedLog = errors.New("no failed check with a log")
+
+// failedLogAction opens the log of the first failed check of the selected
+// pull request.
+func failedLogAction(client gh.ClientFunc) ui.Action {
+	return ui.Action{
+		Key:  "J",
+		Help: "failed log",
+		Applies: func(_ string, it ui.Item) bool {
+			return actionable(it) && pullRequestOf(it).CIStatus == cistatus.CIStatusFailure
+		},
+		Run: func(it ui.Item, _ ui.ActionInput) (ui.ActionResult, error) {
+			p := pullRequestOf(it)
+			c, err := client(p.host())
+			if err != nil {
+				return ui.ActionResult{}, err
+			}
+			checks, err := gh.GetPRChecks(c, p.NodeID)
+			if err != nil {
+				return ui.ActionResult{}, err
+			}
+			for _, check := range checks {
+				if check.Failed() && check.DetailsURL != "" {
+					return ui.ActionResult{Status: "→ Opening log of " + check.Name + "…", Open: check.DetailsURL}, nil
+				}
+			}
+			return ui.ActionResult{}, errNoFailedLog
+		},
+	}
+}
+
+var errNoFailedRuns = errors.New("no failed GitHub Actions runs to re-run")
+
+// rerunAction re-runs the failed GitHub Actions check suites of the selected
+// pull request.
+func rerunAction(client gh.ClientFunc) ui.Action {
+	return ui.Action{
+		Key:  "F",
+		Help: "re-run failed",
+		Applies: func(_ string, it ui.Item) bool {
+			return actionable(it) && pullRequestOf(it).CIStatus == cistatus.CIStatusFailure
+		},
+		Run: func(it ui.Item, _ ui.ActionInput) (ui.ActionResult, error) {
+			p := pullRequestOf(it)
+			c, err := client(p.host())
+			if err != nil {
+				return ui.ActionResult{}, err
+			}
+			failed, err := gh.GetFailedCheckSuites(c, p.NodeID)
+			if err != nil {
+				return ui.ActionResult{}, err
+			}
+			if len(failed.IDs) == 0 {
+				return ui.ActionResult{}, errNoFailedRuns
+			}
+			for _, id := range failed.IDs {
+				if err := gh.RerequestCheckSuite(c, failed.RepositoryID, id); err != nil {
+					return ui.ActionResult{}, err
+				}
+			}
+			return ui.ActionResult{Status: fmt.Sprintf("Re-running %d failed run(s) of %s", len(failed.IDs), p.reference())}, nil
+		},
+	}
+}
+
+var updateMethods = map[string]gh.UpdateMethod{
+	"merge":  gh.UpdateMethodMerge,
+	"rebase": gh.UpdateMethodRebase,
+}
+
+// updateBranchAction brings the branch of the selected pull request of the
+// Created tab up to date with its base branch, if it is behind.
+func updateBranchAction(client gh.ClientFunc) ui.Action {
+	return ui.Action{
+		Key:  "U",
+		Help: "update branch",
+		Applies: func(tab string, it ui.Item) bool {
+			return tab == "created" && actionable(it) &&
+				pullRequestOf(it).MergeStatus == mergestatus.MergeStatusBehind
+		},
+		Choices: func(ui.Item) []string { return []string{"merge", "rebase"} },
+		Run: func(it ui.Item, in ui.ActionInput) (ui.ActionResult, error) {
+			p := pullRequestOf(it)
+			c, err := client(p.host())
+			if err != nil {
+				return ui.ActionResult{}, err
+			}
+			if err := gh.UpdatePullRequestBranch(c, p.NodeID, updateMethods[in.Choice]); err != nil {
+				return ui.ActionResult{}, err
+			}
+			return ui.ActionResult{Status: "Updated the branch of " + p.reference() + " (" + in.Choice + ")"}, nil
+		},
+	}
+}
+
+// labelAction adds labels to the selected or marked pull requests.
+func labelAction(client gh.ClientFunc) ui.Action {
+	return ui.Action{
+		Key:     "L",
+		Help:    "label",
+		Bulk:    true,
+		Applies: func(_ string, it ui.Item) bool { return actionable(it) },
+		Prompt:  func(ui.Item, string) ui.Prompt { return ui.Prompt{Label: "Labels (comma-separated)", Required: true} },
+		Run: func(it ui.Item, in ui.ActionInput) (ui.ActionResult, error) {
+			p := pullRequestOf(it)
+			c, err := client(p.host())
+			if err != nil {
+				return ui.ActionResult{}, err
+			}
+			if err := gh.AddLabelsByName(c, p.repositoryFullName(), p.NodeID, in.List()); err != nil {
+				return ui.ActionResult{}, err
+			}
+			return ui.ActionResult{Status: "Labeled " + p.reference()}, nil
+		},
+	}
+}
+
+// draftAction converts the selected or marked pull requests to drafts if
+// draft is set, and marks drafts as ready for review otherwise. Both variants
+// are bound to the same key, so it toggles the draft state, and the number
+// is re-rendered in place.
+func draftAction(client gh.ClientFunc, draft bool) ui.Action {
+	help, done, mark := "ready for review", "as ready for review", gh.MarkReadyForReview
+	if draft {
+		help, done, mark = "convert to draft", "as draft", gh.ConvertToDraft
+	}
+	return ui.Action{
+		Key:  "D",
+		Help: help,
+		Bulk: true,
+		Applies: func(_ string, it ui.Item) bool {
+			return actionable(it) && pullRequestOf(it).Draft != draft
+		},
+		Run: func(it ui.Item, _ ui.ActionInput) (ui.ActionResult, error) {
+			p := pullRequestOf(it)
+			c, err := client(p.host())
+			if err != nil {
+				return ui.ActionResult{}, err
+			}
+			if err := mark(c, p.NodeID); err != nil {
+				return ui.ActionResult{}, err
+			}
+			return ui.ActionResult{
+				Status: "Marked " + p.reference() + " " + done,
+				Update: func(it ui.Item) ui.Item {
+					updated := pullRequestOf(it)
+					updated.Draft = draft
+					return it.WithTitleText(updated.titleText()).WithData(updated)
+				},
+			}, nil
+		},
+	}
+}
+
+// commentAction posts a comment on the selected pull request, composed from a
+// quote of its latest comment.
+func commentAction(client gh.ClientFunc) ui.Action {
+	return ui.Action{
+		Key:     "C",
+		Help:    "comment",
+		Applies: func(_ string, it ui.Item) bool { return actionable(it) },
+		Compose: func(it ui.Item) (string, error) {
+			p := pullRequestOf(it)
+			c, err := client(p.host())
+			if err != nil {
+				return "", err
+			}
+			latest, err := gh.GetLatestComment(c, p.NodeID)
+			if err != nil || latest == nil {
+				return "", err
+			}
+			return latest.Quote(), nil
+		},
+		Run: func(it ui.Item, in ui.ActionInput) (ui.ActionResult, error) {
+			p := pullRequestOf(it)
+			c, err := client(p.host())
+			if err != nil {
+				return ui.ActionResult{}, err
+			}
+			if err := gh.AddComment(c, p.NodeID, in.Text); err != nil {
+				return ui.ActionResult{}, err
+			}
+			return ui.ActionResult{Status: "Commented on " + p.reference()}, nil
+		},
+	}
+}
+
+// closeAction closes the selected or marked pull requests without merging
+// them, once confirmed.
+func closeAction(client gh.ClientFunc) ui.Action {
+	return ui.Action{
+		Key:     "X",
+		Help:    "close",
+		Bulk:    true,
+		Applies: func(_ string, it ui.Item) bool { return actionable(it) },
+		Choices: func(ui.Item) []string { return []string{"close without merging"} },
+		Run: func(it ui.Item, _ ui.ActionInput) (ui.ActionResult, error) {
+			p := pullRequestOf(it)
+			c, err := client(p.host())
+			if err != nil {
+				return ui.ActionResult{}, err
+			}
+			if err := gh.ClosePullRequest(c, p.NodeID); err != nil {
+				return ui.ActionResult{}, err
+			}
+			return ui.ActionResult{Status: "Closed " + p.reference(), Remove: true}, nil
+		},
+	}
+}
+
+// mergeBlocker explains why a pull request cannot be merged right now, or
+// returns nil if GitHub may accept the merge.
+func mergeBlocker(mb gh.Mergeability) error {
+	if mb.Mergeable == "CONFLICTING" {
+		return errConflicts
+	}
+	switch mb.MergeStateStatus {
+	case "BEHIND":
+		return errors.New("the branch is out of date with the base branch")
+	case "BLOCKED":
+		return errors.New("merging is blocked by required reviews or checks")
+	case "DRAFT":
+		return errors.New("the pull request is a draft")
+	}
+	return nil
+}
+
+// pullRequestOf returns the pull request it was built from.
+func pullRequestOf(it ui.Item) pullRequest {
+	if p, ok := it.Data().(pullRequest); ok {
+		return p
+	}
+	return pullRequest{}
+}
+
+// actionable reports whether it is a pull request that can be acted on. Items
+// from a result cache written before node IDs were fetched cannot.
+func actionable(it ui.Item) bool {
+	p, ok := it.Data().(pullRequest)
+	return ok && p.NodeID != ""
+}
+
+// reference returns the short form of the pull request, e.g. "owner/repo#12".
+func (p pullRequest) reference() string {
+	return fmt.Sprintf("%s#%d", p.repositoryDisplayName(), p.Number)
+}
@@ -0,0 +1,416 @@
+package pr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/snrsw/gh-own/internal/cistatus"
+	"github.com/snrsw/gh-own/internal/gh"
+	"github.com/snrsw/gh-own/internal/ghtest"
+	"github.com/snrsw/gh-own/internal/mergestatus"
+	"github.com/snrsw/gh-own/internal/ui"
+)
+
+func testItem(p pullRequest) ui.Item {
+	return p.toItem("")
+}
+
+func TestReviewAction(t *testing.T) {
+	fake := ghtest.NewGitHub(t, `{"data":{"addPullRequestReview":{"pullRequestReview":{"state":"APPROVED"}}}}`)
+	action := ghtest.FindAction(t, Actions(fake.Client), "R")
+	item := testItem(pullRequest{
+		NodeID:        "PR_12",
+		Number:        12,
+		RepositoryURL: "https://api.github.com/repos/owner/repo",
+		HTMLURL:       "https://github.com/owner/repo/pull/12",
+	})
+
+	if !action.Applies("reviewRequested", item) {
+		t.Fatal("review should apply to pull requests")
+	}
+	if got := action.Choices(item); len(got) != 3 {
+		t.Errorf("Choices() = %v, want 3 review events", got)
+	}
+	if p := action.Prompt(item, "approve"); p.Required {
+		t.Error("approving should not require a comment")
+	}
+	if p := action.Prompt(item, "request changes"); !p.Required {
+		t.Error("requesting changes should require a comment")
+	}
+
+	result, err := action.Run(item, ui.ActionInput{Choice: "approve"})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if result.Status != "Approved owner/repo#12" {
+		t.Errorf("Status = %q, want %q", result.Status, "Approved owner/repo#12")
+	}
+	if len(fake.Requests) != 1 {
+		t.Fatalf("made %d requests, want 1", len(fake.Requests))
+	}
+	if v := fake.Requests[0].Variables; v["id"] != "PR_12" || v["event"] != string(gh.ReviewApprove) {
+		t.Errorf("variables = %v, want id PR_12 and event APPROVE", v)
+	}
+}
+
+func TestActions_NotApplicableWithoutNodeID(t *testing.T) {
+	item := testItem(pullRequest{Number: 12, HTMLURL: "https://github.com/owner/repo/pull/12"})
+
+	for _, a := range Actions(ghtest.NewGitHub(t).Client) {
+		if a.Applies("created", item) {
+			t.Errorf("action %q should not apply to a pull request without a node ID", a.Key)
+		}
+	}
+}
+
+func mergeTestItem(ci cistatus.CIStatus) ui.Item {
+	return testItem(pullRequest{
+		NodeID:        "PR_7",
+		Number:        7,
+		RepositoryURL: "https://api.github.com/repos/owner/repo",
+		HTMLURL:       "https://github.com/owner/repo/pull/7",
+		CIStatus:      ci,
+	})
+}
+
+func TestMergeAction_Applies(t *testing.T) {
+	action := ghtest.FindAction(t, Actions(ghtest.NewGitHub(t).Client), "m")
+
+	if !action.Applies("created", mergeTestItem(cistatus.CIStatusSuccess)) {
+		t.Error("merge should apply in the Created tab")
+	}
+	if action.Applies("reviewRequested", mergeTestItem(cistatus.CIStatusSuccess)) {
+		t.Error("merge should only apply in the Created tab")
+	}
+	draft := testItem(pullRequest{NodeID: "PR_7", Draft: true})
+	if action.Applies("created", draft) {
+		t.Error("merge should not apply to drafts")
+	}
+}
+
+func TestMergeAction_Choices(t *testing.T) {
+	action := ghtest.FindAction(t, Actions(ghtest.NewGitHub(t).Client), "m")
+
+	if got := action.Choices(mergeTestItem(cistatus.CIStatusSuccess)); len(got) != 3 {
+		t.Errorf("Choices() = %v, want the 3 merge methods", got)
+	}
+	got := action.Choices(mergeTestItem(cistatus.CIStatusPending))
+	if len(got) != 6 || !strings.HasPrefix(got[0], autoMergePrefix) {
+		t.Errorf("Choices() with pending checks = %v, want auto-merge choices first", got)
+	}
+}
+
+func TestMergeAction_Run(t *testing.T) {
+	tests := []struct {
+		name         string
+		choice       string
+		mergeability string
+		wantErr      string
+		wantMutation string
+		wantRemove   bool
+	}{
+		{
+			name:         "merges clean pull request",
+			choice:       "squash",
+			mergeability: `{"data":{"node":{"mergeable":"MERGEABLE","mergeStateStatus":"CLEAN"}}}`,
+			wantMutation: "mergePullRequest",
+			wantRemove:   true,
+		},
+		{
+			name:         "refuses conflicting pull request",
+			choice:       "merge",
+			mergeability: `{"data":{"node":{"mergeable":"CONFLICTING","mergeStateStatus":"DIRTY"}}}`,
+			wantErr:      "conflicts",
+		},
+		{
+			name:         "refuses blocked pull request",
+			choice:       "merge",
+			mergeability: `{"data":{"node":{"mergeable":"MERGEABLE","mergeStateStatus":"BLOCKED"}}}`,
+			wantErr:      "blocked",
+		},
+		{
+			name:         "enables auto-merge",
+			choice:       autoMergePrefix + "rebase",
+			mergeability: `{"data":{"node":{"mergeable":"MERGEABLE","mergeStateStatus":"BLOCKED"}}}`,
+			wantMutation: "enablePullRequestAutoMerge",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := ghtest.NewGitHub(t, tt.mergeability)
+			action := ghtest.FindAction(t, Actions(fake.Client), "m")
+
+			result, err := action.Run(mergeTestItem(cistatus.CIStatusPending), ui.ActionInput{Choice: tt.choice})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Run() error = %v, want %q", err, tt.wantErr)
+				}
+				if len(fake.Requests) != 1 {
+					t.Errorf("made %d requests, want only the mergeability check", len(fake.Requests))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run() error: %v", err)
+			}
+			if len(fake.Requests) != 2 || !strings.Contains(fake.Requests[1].Query, tt.wantMutation) {
+				t.Fatalf("requests = %+v, want mergeability check then %s", fake.Requests, tt.wantMutation)
+			}
+			if result.Remove != tt.wantRemove {
+				t.Errorf("Remove = %v, want %v", result.Remove, tt.wantRemove)
+			}
+		})
+	}
+}
+
+func TestFailedLogAction(t *testing.T) {
+	checks := `{"data":{"node":{"commits":{"nodes":[{"commit":{"statusCheckRollup":{"contexts":{"nodes":[
+		{"name":"build","status":"COMPLETED","conclusion":"SUCCESS","detailsUrl":"https://github.com/owner/repo/actions/runs/1/job/1"},
+		{"name":"lint","status":"COMPLETED","conclusion":"FAILURE","detailsUrl":"https://github.com/owner/repo/actions/runs/1/job/2"}
+	]}}}}]}}}}`
+	fake := ghtest.NewGitHub(t, checks)
+	action := ghtest.FindAction(t, Actions(fake.Client), "J")
+
+	if action.Applies("created", mergeTestItem(cistatus.CIStatusSuccess)) {
+		t.Error("failed log should only apply to pull requests with failed CI")
+	}
+	item := mergeTestItem(cistatus.CIStatusFailure)
+	if !action.Applies("created", item) {
+		t.Fatal("failed log should apply to pull requests with failed CI")
+	}
+
+	result, err := action.Run(item, ui.ActionInput{})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if want := "https://github.com/owner/repo/actions/runs/1/job/2"; result.Open != want {
+		t.Errorf("Open = %q, want %q", result.Open, want)
+	}
+	if fake.Requests[0].Variables["id"] != "PR_7" {
+		t.Errorf("id = %v, want PR_7", fake.Requests[0].Variables["id"])
+	}
+}
+
+func TestFailedLogAction_NoLog(t *testing.T) {
+	fake := ghtest.NewGitHub(t, `{"data":{"node":{"commits":{"nodes":[{"commit":{"statusCheckRollup":{"contexts":{"nodes":[
+		{"context":"ci/legacy","state":"FAILURE"}
+	]}}}}]}}}}`)
+	action := ghtest.FindAction(t, Actions(fake.Client), "J")
+
+	if _, err := action.Run(mergeTestItem(cistatus.CIStatusFailure), ui.ActionInput{}); !errors.Is(err, errNoFailedLog) {
+		t.Errorf("Run() error = %v, want %v", err, errNoFailedLog)
+	}
+}
+
+func TestRerunAction(t *testing.T) {
+	fake := ghtest.NewGitHub(t,
+		`{"data":{"node":{"repository":{"id":"R_1"},"commits":{"nodes":[{"commit":{"checkSuites":{"nodes":[
+			{"id":"CS_1","conclusion":"FAILURE","app":{"slug":"github-actions"}},
+			{"id":"CS_2","conclusion":"FAILURE","app":{"slug":"github-actions"}}
+		]}}}]}}}}`,
+	)
+	action := ghtest.FindAction(t, Actions(fake.Client), "F")
+
+	if action.Applies("created", mergeTestItem(cistatus.CIStatusPending)) {
+		t.Error("re-run should only apply to pull requests with failed CI")
+	}
+	item := mergeTestItem(cistatus.CIStatusFailure)
+	if !action.Applies("assigned", item) {
+		t.Fatal("re-run should apply to pull requests with failed CI")
+	}
+
+	result, err := action.Run(item, ui.ActionInput{})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if want := "Re-running 2 failed run(s) of owner/repo#7"; result.Status != want {
+		t.Errorf("Status = %q, want %q", result.Status, want)
+	}
+	if len(fake.Requests) != 3 {
+		t.Fatalf("made %d requests, want 1 query and 2 mutations", len(fake.Requests))
+	}
+	for i, id := range []string{"CS_1", "CS_2"} {
+		if v := fake.Requests[i+1].Variables; v["repositoryId"] != "R_1" || v["checkSuiteId"] != id {
+			t.Errorf("mutation %d variables = %v, want repositoryId R_1 and checkSuiteId %s", i, v, id)
+		}
+	}
+}
+
+func TestRerunAction_NothingToRerun(t *testing.T) {
+	fake := ghtest.NewGitHub(t, `{"data":{"node":{"repository":{"id":"R_1"},"commits":{"nodes":[]}}}}`)
+	action := ghtest.FindAction(t, Actions(fake.Client), "F")
+
+	if _, err := action.Run(mergeTestItem(cistatus.CIStatusFailure), ui.ActionInput{}); !errors.Is(err, errNoFailedRuns) {
+		t.Errorf("Run() error = %v, want %v", err, errNoFailedRuns)
+	}
+}
+
+func TestUpdateBranchAction(t *testing.T) {
+	fake := ghtest.NewGitHub(t, `{"data":{"updatePullRequestBranch":{"pullRequest":{"number":7}}}}`)
+	action := ghtest.FindAction(t, Actions(fake.Client), "U")
+
+	item := testItem(pullRequest{
+		NodeID:        "PR_7",
+		Number:        7,
+		RepositoryURL: "https://api.github.com/repos/owner/repo",
+		HTMLURL:       "https://github.com/owner/repo/pull/7",
+		MergeStatus:   mergestatus.MergeStatusBehind,
+	})
+	if !action.Applies("created", item) {
+		t.Fatal("update branch should apply to a pull request behind its base in the Created tab")
+	}
+	if action.Applies("assigned", item) {
+		t.Error("update branch should only apply in the Created tab")
+	}
+	for _, status := range []mergestatus.MergeStatus{mergestatus.MergeStatusClean, mergestatus.MergeStatusUnknown, mergestatus.MergeStatusConflicting} {
+		if action.Applies("created", testItem(pullRequest{NodeID: "PR_7", MergeStatus: status})) {
+			t.Errorf("update branch should not apply to %s pull requests", status)
+		}
+	}
+
+	result, err := action.Run(item, ui.ActionInput{Choice: "rebase"})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if want := "Updated the branch of owner/repo#7 (rebase)"; result.Status != want {
+		t.Errorf("Status = %q, want %q", result.Status, want)
+	}
+	if v := fake.Requests[0].Variables; v["id"] != "PR_7" || v["method"] != string(gh.UpdateMethodRebase) {
+		t.Errorf("variables = %v, want id PR_7 and method REBASE", v)
+	}
+}
+
+func TestLabelAction(t *testing.T) {
+	fake := ghtest.NewGitHub(t,
+		`{"data":{"repository":{"labels":{"nodes":[{"id":"LA_1","name":"bug"},{"id":"LA_2","name":"help wanted"}]}}}}`,
+		`{"data":{}}`,
+	)
+	action := ghtest.FindAction(t, Actions(fake.Client), "L")
+	if !action.Bulk {
+		t.Error("label should support marked items")
+	}
+
+	result, err := action.Run(mergeTestItem(cistatus.CIStatusSuccess), ui.ActionInput{Text: "bug, help wanted"})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if result.Status != "Labeled owner/repo#7" {
+		t.Errorf("Status = %q, want %q", result.Status, "Labeled owner/repo#7")
+	}
+	if v := fake.Requests[0].Variables; v["owner"] != "owner" || v["name"] != "repo" {
+		t.Errorf("labels query variables = %v, want owner/repo", v)
+	}
+	labels, ok := fake.Requests[1].Variables["labels"].([]any)
+	if !ok || fake.Requests[1].Variables["id"] != "PR_7" || len(labels) != 2 {
+		t.Errorf("mutation variables = %v, want id PR_7 and two labels", fake.Requests[1].Variables)
+	}
+}
+
+func TestReadyAction(t *testing.T) {
+	fake := ghtest.NewGitHub(t, `{"data":{}}`)
+	action := ghtest.FindAction(t, Actions(fake.Client), "D")
+
+	if action.Applies("created", mergeTestItem(cistatus.CIStatusSuccess)) {
+		t.Error("ready for review should only apply to drafts")
+	}
+	draft := testItem(pullRequest{
+		NodeID:        "PR_7",
+		Number:        7,
+		RepositoryURL: "https://api.github.com/repos/owner/repo",
+		HTMLURL:       "https://github.com/owner/repo/pull/7",
+		Draft:         true,
+	})
+	if !action.Applies("created", draft) {
+		t.Fatal("ready for review should apply to drafts")
+	}
+
+	if _, err := action.Run(draft, ui.ActionInput{}); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if !strings.Contains(fake.Requests[0].Query, "markPullRequestReadyForReview") {
+		t.Errorf("query = %q, want markPullRequestReadyForReview", fake.Requests[0].Query)
+	}
+}
+
+func TestDraftAction(t *testing.T) {
+	fake := ghtest.NewGitHub(t, `{"data":{}}`)
+	var action ui.Action
+	for _, a := range Actions(fake.Client) {
+		if a.Key == "D" && a.Help == "convert to draft" {
+			action = a
+		}
+	}
+	if action.Run == nil {
+		t.Fatal("no convert to draft action bound to D")
+	}
+
+	it := mergeTestItem(cistatus.CIStatusSuccess)
+	if !action.Applies("created", it) {
+		t.Fatal("convert to draft should apply to pull requests ready for review")
+	}
+	result, err := action.Run(it, ui.ActionInput{})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if !strings.Contains(fake.Requests[0].Query, "convertPullRequestToDraft") {
+		t.Errorf("query = %q, want convertPullRequestToDraft", fake.Requests[0].Query)
+	}
+	if result.Update == nil {
+		t.Fatal("Update should re-render the pull request as a draft")
+	}
+	updated := result.Update(it)
+	if !pullRequestOf(updated).Draft {
+		t.Error("updated item should be a draft")
+	}
+	if action.Applies("created", updated) {
+		t.Error("convert to draft should not apply to a draft")
+	}
+}
+
+func TestCloseAction(t *testing.T) {
+	fake := ghtest.NewGitHub(t, `{"data":{}}`)
+	action := ghtest.FindAction(t, Actions(fake.Client), "X")
+
+	if got := action.Choices(mergeTestItem(cistatus.CIStatusSuccess)); len(got) != 1 {
+		t.Errorf("Choices() = %v, want a single confirmation", got)
+	}
+	result, err := action.Run(mergeTestItem(cistatus.CIStatusSuccess), ui.ActionInput{})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if !result.Remove {
+		t.Error("a closed pull request should be removed from the tabs")
+	}
+	if !strings.Contains(fake.Requests[0].Query, "closePullRequest") {
+		t.Errorf("query = %q, want closePullRequest", fake.Requests[0].Query)
+	}
+}
+
+func TestCommentAction(t *testing.T) {
+	fake := ghtest.NewGitHub(t,
+		`{"data":{"node":{"comments":{"nodes":[{"author":{"login":"alice"},"body":"@octocat can you take a look?"}]}}}}`,
+		`{"data":{}}`,
+	)
+	action := ghtest.FindAction(t, Actions(fake.Client), "C")
+	it := mergeTestItem(cistatus.CIStatusSuccess)
+
+	draft, err := action.Compose(it)
+	if err != nil {
+		t.Fatalf("Compose() error: %v", err)
+	}
+	if draft != "> @octocat can you take a look?\n\n" {
+		t.Errorf("Compose() = %q, want a quote of the latest comment", draft)
+	}
+
+	result, err := action.Run(it, ui.ActionInput{Text: "On it."})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if result.Status != "Commented on owner/repo#7" {
+		t.Errorf("Status = %q", result.Status)
+	}
+	if v := fake.Requests[1].Variables; v["id"] != "PR_7" || v["body"] != "On it." {
+		t.Errorf("addComment variables = %v, want id PR_7 and the message", v)
+	}
+}
@@ -0,0 +1,67 @@
+// Package pr provides functionality to handle GitHub pull requests owned by a user.
+package pr
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/snrsw/gh-own/internal/config"
+	"github.com/snrsw/gh-own/internal/ui"
+)
+
+// runCommand runs name with args in dir. Tests replace it.
+var runCommand = func(dir, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%s %s: %s", name, args[0], msg)
+		}
+		return fmt.Errorf("%s %s: %w", name, args[0], err)
+	}
+	return nil
+}
+
+// CheckoutAction returns the action checking out the selected pull request
+// in the local clone of its repository, as configured in cfg.
+func CheckoutAction(cfg config.CheckoutConfig) ui.Action {
+	return ui.Action{
+		Key:  "c",
+		Help: "checkout",
+		Applies: func(_ string, it ui.Item) bool {
+			p, ok := it.Data().(pullRequest)
+			return ok && cfg.Repos[p.repositoryDisplayName()] != ""
+		},
+		Run: func(it ui.Item, _ ui.ActionInput) (ui.ActionResult, error) {
+			p := pullRequestOf(it)
+			dir, err := checkout(cfg, p)
+			if err != nil {
+				return ui.ActionResult{}, err
+			}
+			return ui.ActionResult{Status: "Checked out " + p.reference() + " in " + dir}, nil
+		},
+	}
+}
+
+// checkout checks p out with gh, in a worktree of the local clone if a
+// worktree root is configured, and returns the directory it is checked out in.
+func checkout(cfg config.CheckoutConfig, p pullRequest) (string, error) {
+	dir := cfg.Repos[p.repositoryDisplayName()]
+	if cfg.WorktreeRoot != "" {
+		worktree := filepath.Join(cfg.WorktreeRoot, p.repositoryDisplayName(), fmt.Sprintf("pr-%d", p.Number))
+		if _, err := os.Stat(worktree); err != nil {
+			if err := runCommand(dir, "git", "worktree", "add", "--detach", worktree); err != nil {
+				return "", err
+			}
+		}
+		dir = worktree
+	}
+	if err := runCommand(dir, "gh", "pr", "checkout", p.HTMLURL); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
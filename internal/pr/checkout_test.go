@@ -0,0 +1,113 @@
+package pr
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/snrsw/gh-own/internal/config"
+	"github.com/snrsw/gh-own/internal/ui"
+)
+
+// fakeCommands replaces runCommand for the test, recording each command as
+// "dir: name args…" and failing with err.
+func fakeCommands(t *testing.T, err error) *[]string {
+	t.Helper()
+	var ran []string
+	orig := runCommand
+	runCommand = func(dir, name string, args ...string) error {
+		ran = append(ran, dir+": "+name+" "+strings.Join(args, " "))
+		return err
+	}
+	t.Cleanup(func() { runCommand = orig })
+	return &ran
+}
+
+func checkoutTestItem() ui.Item {
+	return testItem(pullRequest{
+		Number:        12,
+		RepositoryURL: "https://api.github.com/repos/owner/repo",
+		HTMLURL:       "https://github.com/owner/repo/pull/12",
+	})
+}
+
+func TestCheckoutAction_Applies(t *testing.T) {
+	action := CheckoutAction(config.CheckoutConfig{Repos: map[string]string{"owner/repo": "/src/repo"}})
+
+	if !action.Applies("reviewRequested", checkoutTestItem()) {
+		t.Error("checkout should apply to pull requests of configured repositories")
+	}
+	other := testItem(pullRequest{RepositoryURL: "https://api.github.com/repos/other/repo"})
+	if action.Applies("reviewRequested", other) {
+		t.Error("checkout should not apply to repositories without a local clone")
+	}
+}
+
+func TestCheckoutAction_Run_InClone(t *testing.T) {
+	ran := fakeCommands(t, nil)
+	action := CheckoutAction(config.CheckoutConfig{Repos: map[string]string{"owner/repo": "/src/repo"}})
+
+	result, err := action.Run(checkoutTestItem(), ui.ActionInput{})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	want := []string{"/src/repo: gh pr checkout https://github.com/owner/repo/pull/12"}
+	if !reflect.DeepEqual(*ran, want) {
+		t.Errorf("ran %v, want %v", *ran, want)
+	}
+	if result.Status != "Checked out owner/repo#12 in /src/repo" {
+		t.Errorf("Status = %q", result.Status)
+	}
+}
+
+func TestCheckoutAction_Run_InWorktree(t *testing.T) {
+	ran := fakeCommands(t, nil)
+	root := t.TempDir()
+	action := CheckoutAction(config.CheckoutConfig{
+		Repos:        map[string]string{"owner/repo": "/src/repo"},
+		WorktreeRoot: root,
+	})
+
+	if _, err := action.Run(checkoutTestItem(), ui.ActionInput{}); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	worktree := filepath.Join(root, "owner", "repo", "pr-12")
+	want := []string{
+		"/src/repo: git worktree add --detach " + worktree,
+		worktree + ": gh pr checkout https://github.com/owner/repo/pull/12",
+	}
+	if !reflect.DeepEqual(*ran, want) {
+		t.Errorf("ran %v, want %v", *ran, want)
+	}
+}
+
+func TestCheckoutAction_Run_ExistingWorktree(t *testing.T) {
+	ran := fakeCommands(t, nil)
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "owner", "repo", "pr-12"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	action := CheckoutAction(config.CheckoutConfig{
+		Repos:        map[string]string{"owner/repo": "/src/repo"},
+		WorktreeRoot: root,
+	})
+
+	if _, err := action.Run(checkoutTestItem(), ui.ActionInput{}); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if len(*ran) != 1 || !strings.Contains((*ran)[0], "gh pr checkout") {
+		t.Errorf("ran %v, want only gh pr checkout in the existing worktree", *ran)
+	}
+}
+
+func TestCheckoutAction_Run_Error(t *testing.T) {
+	fakeCommands(t, errors.New("gh pr checkout: not a git repository"))
+	action := CheckoutAction(config.CheckoutConfig{Repos: map[string]string{"owner/repo": "/src/repo"}})
+
+	if _, err := action.Run(checkoutTestItem(), ui.ActionInput{}); err == nil {
+		t.Error("Run() should return the command error")
+	}
+}
@@ -0,0 +1,31 @@
+// Package pr provides functionality to handle GitHub pull requests owned by a user.
+package pr
+
+import (
+	"fmt"
+
+	"github.com/snrsw/gh-own/internal/detail"
+	"github.com/snrsw/gh-own/internal/gh"
+	"github.com/snrsw/gh-own/internal/ui"
+)
+
+// Detail returns the function fetching the detail view of pull request items.
+func Detail(client gh.ClientFunc) ui.DetailFunc {
+	return func(it ui.Item) (func(width int) string, error) {
+		p, ok := it.Data().(pullRequest)
+		if !ok || p.NodeID == "" {
+			return nil, ui.ErrNotFetched
+		}
+		c, err := client(p.host())
+		if err != nil {
+			return nil, err
+		}
+		d, err := gh.GetPRDetail(c, p.NodeID)
+		if err != nil {
+			return nil, err
+		}
+		title := fmt.Sprintf("%s #%d %s", p.repositoryDisplayName(), p.Number, p.Title)
+		login, _ := gh.CurrentLoginForHost(p.host())
+		return func(width int) string { return detail.Render(title, d, width, login) }, nil
+	}
+}
@@ -0,0 +1,49 @@
+package pr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/snrsw/gh-own/internal/ghtest"
+	"github.com/snrsw/gh-own/internal/ui"
+)
+
+func TestDetail(t *testing.T) {
+	fake := ghtest.NewGitHub(t, `{"data":{"node":{"body":"Fixes the flaky test.","labels":{"nodes":[{"name":"bug"}]}}}}`)
+	item := testItem(pullRequest{
+		NodeID:        "PR_12",
+		Number:        12,
+		Title:         "Fix bug",
+		RepositoryURL: "https://api.github.com/repos/owner/repo",
+		HTMLURL:       "https://github.com/owner/repo/pull/12",
+	})
+
+	render, err := Detail(fake.Client)(item)
+	if err != nil {
+		t.Fatalf("Detail() error: %v", err)
+	}
+	if len(fake.Requests) != 1 || fake.Requests[0].Variables["id"] != "PR_12" {
+		t.Errorf("requests = %v, want one for PR_12", fake.Requests)
+	}
+
+	got := ansi.Strip(render(80))
+	for _, want := range []string{"owner/repo #12 Fix bug", "Labels: bug", "flaky"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("render() missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestDetail_WithoutNodeID(t *testing.T) {
+	fake := ghtest.NewGitHub(t)
+	item := testItem(pullRequest{Number: 12, HTMLURL: "https://github.com/owner/repo/pull/12"})
+
+	if _, err := Detail(fake.Client)(item); !errors.Is(err, ui.ErrNotFetched) {
+		t.Errorf("Detail() error = %v, want %v", err, ui.ErrNotFetched)
+	}
+	if len(fake.Requests) != 0 {
+		t.Errorf("made %d requests, want none", len(fake.Requests))
+	}
+}
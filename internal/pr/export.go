@@ -0,0 +1,105 @@
+// Package pr provides functionality to handle GitHub pull requests owned by a user.
+package pr
+
+import (
+	"fmt"
+
+	"github.com/snrsw/gh-own/internal/output"
+	"github.com/snrsw/gh-own/internal/ui"
+)
+
+// ExportFields lists the JSON fields available for pull requests.
+var ExportFields = []string{
+	"author",
+	"ciStatus",
+	"createdAt",
+	"host",
+	"isDraft",
+	"latestActivity",
+	"mergeStatus",
+	"number",
+	"repository",
+	"reviewStatus",
+	"title",
+	"updatedAt",
+	"url",
+}
+
+// Export converts grouped pull requests into records grouped by tab, in the
+// sort configured for each tab.
+func (o *GroupedPullRequests) Export() []output.Group {
+	groups := o.tabGroups()
+	exported := make([]output.Group, 0, len(groups))
+	for _, g := range groups {
+		records := make([]output.Record, 0, len(g.result.Items))
+		for _, p := range sortPullRequests(g.result.Items, o.sort[g.key]) {
+			records = append(records, p.record())
+		}
+		exported = append(exported, output.Group{Key: g.key, Name: g.name, Records: records})
+	}
+	return exported
+}
+
+// Warnings describes the tabs whose search failed, in tab order.
+func (o *GroupedPullRequests) Warnings() []string {
+	var warnings []string
+	for _, g := range o.tabGroups() {
+		if g.err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %v", g.name, g.err))
+		}
+	}
+	return warnings
+}
+
+// Table converts grouped pull requests into a plain table with one row per pull
+// request, in the sort configured for each tab.
+func (o *GroupedPullRequests) Table() output.Table {
+	t := output.Table{Headers: []string{"TAB", "REPO", "NUMBER", "TITLE", "CI", "REVIEW", "LATEST ACTIVITY"}}
+	for _, g := range o.tabGroups() {
+		for _, p := range sortPullRequests(g.result.Items, o.sort[g.key]) {
+			t.Rows = append(t.Rows, p.row(g.key))
+		}
+	}
+	return t
+}
+
+func (p pullRequest) row(tab string) []string {
+	return []string{
+		tab,
+		p.repositoryDisplayName(),
+		fmt.Sprintf("#%d", p.Number),
+		p.Title,
+		p.CIStatus.String(),
+		p.ReviewStatus.String(),
+		p.activitySummary(),
+	}
+}
+
+func (p pullRequest) activitySummary() string {
+	if p.LatestActivity.Login == "" {
+		return "updated " + ui.UpdatedAgo(p.UpdatedAt)
+	}
+	return fmt.Sprintf("%s by @%s %s", p.LatestActivity.Kind, p.LatestActivity.Login, ui.UpdatedAgo(p.LatestActivity.At))
+}
+
+func (p pullRequest) record() output.Record {
+	var activity any
+	if p.LatestActivity.Login != "" {
+		activity = p.LatestActivity
+	}
+	return output.Record{
+		"author":         p.User.Login,
+		"ciStatus":       p.CIStatus.String(),
+		"createdAt":      p.CreatedAt,
+		"host":           p.host(),
+		"isDraft":        p.Draft,
+		"latestActivity": activity,
+		"mergeStatus":    p.MergeStatus.String(),
+		"number":         p.Number,
+		"repository":     p.repositoryFullName(),
+		"reviewStatus":   p.ReviewStatus.String(),
+		"title":          p.Title,
+		"updatedAt":      p.UpdatedAt,
+		"url":            p.HTMLURL,
+	}
+}
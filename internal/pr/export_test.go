@@ -0,0 +1,151 @@
+package pr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/snrsw/gh-own/internal/cistatus"
+	"github.com/snrsw/gh-own/internal/gh"
+	"github.com/snrsw/gh-own/internal/output"
+	"github.com/snrsw/gh-own/internal/reviewstatus"
+)
+
+func TestExport_GroupsInTabOrder(t *testing.T) {
+	grouped := &GroupedPullRequests{
+		Created: gh.SearchResult[pullRequest]{TotalCount: 1, Items: []pullRequest{{Number: 1}}},
+		Custom: map[string]gh.SearchResult[pullRequest]{
+			"zeta":  {TotalCount: 0, Items: []pullRequest{}},
+			"alpha": {TotalCount: 0, Items: []pullRequest{}},
+		},
+	}
+
+	groups := grouped.Export()
+
+	wantKeys := []string{"created", "participated", "assigned", "reviewRequested", "alpha", "zeta"}
+	if len(groups) != len(wantKeys) {
+		t.Fatalf("Export() returned %d groups, want %d", len(groups), len(wantKeys))
+	}
+	for i, want := range wantKeys {
+		if groups[i].Key != want {
+			t.Errorf("groups[%d].Key = %q, want %q", i, groups[i].Key, want)
+		}
+	}
+	if groups[3].Name != "Review Requested" {
+		t.Errorf("groups[3].Name = %q, want %q", groups[3].Name, "Review Requested")
+	}
+	if len(groups[0].Records) != 1 {
+		t.Errorf("created has %d records, want 1", len(groups[0].Records))
+	}
+}
+
+func TestPullRequest_Record(t *testing.T) {
+	p := pullRequest{
+		Number:        42,
+		Title:         "Add feature",
+		HTMLURL:       "https://github.com/owner/repo/pull/42",
+		RepositoryURL: "https://api.github.com/repos/owner/repo",
+		User:          gh.User{Login: "alice"},
+		Draft:         true,
+		CIStatus:      cistatus.CIStatusFailure,
+		ReviewStatus:  reviewstatus.ReviewStatusApproved,
+	}
+
+	r := p.record()
+
+	checks := map[string]any{
+		"number":       42,
+		"title":        "Add feature",
+		"url":          "https://github.com/owner/repo/pull/42",
+		"repository":   "owner/repo",
+		"author":       "alice",
+		"isDraft":      true,
+		"ciStatus":     "failure",
+		"reviewStatus": "approved",
+	}
+	for k, want := range checks {
+		if r[k] != want {
+			t.Errorf("record[%q] = %v, want %v", k, r[k], want)
+		}
+	}
+	if r["latestActivity"] != nil {
+		t.Errorf("record[latestActivity] = %v, want nil", r["latestActivity"])
+	}
+}
+
+func TestPullRequest_Record_HasAllExportFields(t *testing.T) {
+	r := pullRequest{}.record()
+
+	fields := make([]string, 0, len(r))
+	for k := range r {
+		fields = append(fields, k)
+	}
+	if err := output.ValidateFields(fields, ExportFields); err != nil {
+		t.Errorf("record has fields missing from ExportFields: %v", err)
+	}
+	if len(r) != len(ExportFields) {
+		t.Errorf("record has %d fields, ExportFields has %d", len(r), len(ExportFields))
+	}
+}
+
+func TestWarnings_ListsFailedTabs(t *testing.T) {
+	grouped := NewGroupedPullRequests(&gh.PRSearchResult{
+		Errors: map[string]error{"reviewRequested": errors.New("timeout")},
+	}, "")
+
+	warnings := grouped.Warnings()
+	if len(warnings) != 1 || warnings[0] != "Review Requested: timeout" {
+		t.Errorf("Warnings() = %v, want [Review Requested: timeout]", warnings)
+	}
+}
+
+func TestTable_OneRowPerPullRequest(t *testing.T) {
+	grouped := &GroupedPullRequests{
+		Created: gh.SearchResult[pullRequest]{TotalCount: 2, Items: []pullRequest{{Number: 1}, {Number: 2}}},
+		ReviewRequested: gh.SearchResult[pullRequest]{TotalCount: 1, Items: []pullRequest{
+			{
+				Number:        3,
+				Title:         "Review me",
+				RepositoryURL: "https://api.github.com/repos/owner/repo",
+				CIStatus:      cistatus.CIStatusPending,
+				ReviewStatus:  reviewstatus.ReviewStatusReviewRequired,
+			},
+		}},
+	}
+
+	table := grouped.Table()
+
+	if len(table.Rows) != 3 {
+		t.Fatalf("Table() returned %d rows, want 3", len(table.Rows))
+	}
+	want := []string{"reviewRequested", "owner/repo", "#3", "Review me", "pending", "review_required"}
+	row := table.Rows[2]
+	for i, w := range want {
+		if row[i] != w {
+			t.Errorf("row[%d] = %q, want %q", i, row[i], w)
+		}
+	}
+	if len(row) != len(table.Headers) {
+		t.Errorf("row has %d cells, want %d", len(row), len(table.Headers))
+	}
+}
+
+func TestPullRequest_ActivitySummary(t *testing.T) {
+	p := pullRequest{
+		LatestActivity: gh.LatestActivity{Kind: "commented", Login: "alice", At: "2020-01-01T00:00:00Z"},
+	}
+
+	got := p.activitySummary()
+
+	if !strings.HasPrefix(got, "commented by @alice ") {
+		t.Errorf("activitySummary() = %q, want prefix %q", got, "commented by @alice ")
+	}
+}
+
+func TestPullRequest_ActivitySummary_NoActivity(t *testing.T) {
+	p := pullRequest{UpdatedAt: ""}
+
+	if got := p.activitySummary(); got != "updated -" {
+		t.Errorf("activitySummary() = %q, want %q", got, "updated -")
+	}
+}
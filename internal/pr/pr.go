@@ -6,7 +6,9 @@ import (
 
 	"github.com/snrsw/gh-own/internal/cistatus"
 	"github.com/snrsw/gh-own/internal/gh"
+	"github.com/snrsw/gh-own/internal/mergestatus"
 	"github.com/snrsw/gh-own/internal/reviewstatus"
+	"github.com/snrsw/gh-own/internal/sortmode"
 )
 
 type GroupedPullRequests struct {
@@ -15,26 +17,34 @@ type GroupedPullRequests struct {
 	ReviewRequested gh.SearchResult[pullRequest]
 	Participated    gh.SearchResult[pullRequest]
 	Custom          map[string]gh.SearchResult[pullRequest]
+	RateLimit       gh.RateLimit
+	Errors          map[string]error
 	currentLogin    string
+	hostLogins      map[string]string
+	sort            map[string]sortmode.SortMode
+	closedTabs      map[string]bool
 }
 
 func NewGroupedPullRequests(ghResult *gh.PRSearchResult, currentLogin string) *GroupedPullRequests {
 	custom := make(map[string]gh.SearchResult[pullRequest], len(ghResult.Custom))
 	for k, nodes := range ghResult.Custom {
-		custom[k] = toSearchResult(nodes)
+		custom[k] = toSearchResult(nodes, ghResult.Totals[k])
 	}
 
 	return &GroupedPullRequests{
-		Created:         toSearchResult(ghResult.Created),
-		Assigned:        toSearchResult(ghResult.Assigned),
-		ReviewRequested: toSearchResult(ghResult.ReviewRequested),
-		Participated:    toSearchResult(ghResult.Participated),
+		Created:         toSearchResult(ghResult.Created, ghResult.Totals["created"]),
+		Assigned:        toSearchResult(ghResult.Assigned, ghResult.Totals["assigned"]),
+		ReviewRequested: toSearchResult(ghResult.ReviewRequested, ghResult.Totals["reviewRequested"]),
+		Participated:    toSearchResult(ghResult.Participated, ghResult.Totals["participated"]),
 		Custom:          custom,
+		RateLimit:       ghResult.RateLimit,
+		Errors:          ghResult.Errors,
 		currentLogin:    currentLogin,
 	}
 }
 
 type pullRequest struct {
+	NodeID         string            `json:"node_id"`
 	Number         int               `json:"number"`
 	User           gh.User           `json:"user"`
 	RepositoryURL  string            `json:"repository_url"`
@@ -46,9 +56,53 @@ type pullRequest struct {
 	CreatedAt      string            `json:"created_at"`
 	CIStatus       cistatus.CIStatus           `json:"-"`
 	ReviewStatus   reviewstatus.ReviewStatus   `json:"-"`
+	MergeStatus    mergestatus.MergeStatus     `json:"-"`
 	LatestActivity gh.LatestActivity           `json:"-"`
 }
 
+// WithHostLogins sets the login used for items on each host, overriding the
+// current login for hosts other than the default one.
+func (o *GroupedPullRequests) WithHostLogins(logins map[string]string) *GroupedPullRequests {
+	o.hostLogins = logins
+	return o
+}
+
+// WithSort sets the sort mode of each tab, keyed like the queries in the
+// config. Tabs without one keep the order the search returned.
+func (o *GroupedPullRequests) WithSort(sort map[string]sortmode.SortMode) *GroupedPullRequests {
+	o.sort = sort
+	return o
+}
+
+// WithClosedTabs sets the keys of the tabs whose queries may match closed
+// items, which keep items closed by an action.
+func (o *GroupedPullRequests) WithClosedTabs(keys map[string]bool) *GroupedPullRequests {
+	o.closedTabs = keys
+	return o
+}
+
+// loginFor returns the current user's login on host.
+func (o *GroupedPullRequests) loginFor(host string) string {
+	if login, ok := o.hostLogins[host]; ok {
+		return login
+	}
+	return o.currentLogin
+}
+
+// host returns the hostname the pull request lives on.
+func (p *pullRequest) host() string {
+	return gh.HostFromURL(p.HTMLURL)
+}
+
+// repositoryDisplayName returns the repository name, prefixed with the host
+// for pull requests outside github.com.
+func (p *pullRequest) repositoryDisplayName() string {
+	if h := p.host(); h != gh.DefaultHostname {
+		return h + "/" + p.repositoryFullName()
+	}
+	return p.repositoryFullName()
+}
+
 func (p *pullRequest) repositoryFullName() string {
 	// Format: "https://api.github.com/repos/owner/repo"
 	parts := strings.Split(p.RepositoryURL, "/")
@@ -58,10 +112,12 @@ func (p *pullRequest) repositoryFullName() string {
 	return parts[len(parts)-2] + "/" + parts[len(parts)-1]
 }
 
-func toSearchResult(nodes []gh.PRSearchNode) gh.SearchResult[pullRequest] {
+// toSearchResult converts nodes into a search result. total is the number of
+// matches reported by GitHub; it never reduces TotalCount below len(nodes).
+func toSearchResult(nodes []gh.PRSearchNode, total int) gh.SearchResult[pullRequest] {
 	prs := fromGraphQLNodes(nodes)
 	return gh.SearchResult[pullRequest]{
-		TotalCount: len(prs),
+		TotalCount: max(total, len(prs)),
 		Items:      prs,
 	}
 }
@@ -76,6 +132,7 @@ func fromGraphQLNodes(nodes []gh.PRSearchNode) []pullRequest {
 
 func fromGraphQL(node gh.PRSearchNode) pullRequest {
 	return pullRequest{
+		NodeID:         node.ID,
 		Number:         node.Number,
 		User:           gh.User{Login: node.Author.Login},
 		RepositoryURL:  node.RepositoryURL(),
@@ -86,6 +143,7 @@ func fromGraphQL(node gh.PRSearchNode) pullRequest {
 		CreatedAt:      node.CreatedAt,
 		CIStatus:       node.CIStatus(),
 		ReviewStatus:   reviewstatus.ParseReviewDecision(node.ReviewDecision),
+		MergeStatus:    node.MergeStatus(),
 		LatestActivity: node.LatestActivity,
 	}
 }
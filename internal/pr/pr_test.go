@@ -1,11 +1,13 @@
 package pr
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
 	"github.com/snrsw/gh-own/internal/cistatus"
 	"github.com/snrsw/gh-own/internal/gh"
+	"github.com/snrsw/gh-own/internal/mergestatus"
 	"github.com/snrsw/gh-own/internal/reviewstatus"
 )
 
@@ -31,6 +33,28 @@ func TestNewGroupedPullRequests_PropagatesCustom(t *testing.T) {
 	}
 }
 
+func TestNewGroupedPullRequests_UsesTotals(t *testing.T) {
+	ghResult := &gh.PRSearchResult{
+		Created:  []gh.PRSearchNode{{Number: 1}, {Number: 2}},
+		Assigned: []gh.PRSearchNode{{Number: 3}},
+		Totals:   map[string]int{"created": 312},
+	}
+
+	grouped := NewGroupedPullRequests(ghResult, "")
+
+	if grouped.Created.TotalCount != 312 {
+		t.Errorf("Created.TotalCount = %d, want 312", grouped.Created.TotalCount)
+	}
+	if grouped.Assigned.TotalCount != 1 {
+		t.Errorf("Assigned.TotalCount = %d, want 1 (falls back to item count)", grouped.Assigned.TotalCount)
+	}
+
+	tabs := grouped.BuildTabs()
+	if tabs[0].Name() != "Created (2 of 312)" {
+		t.Errorf("tabs[0].Name() = %q, want %q", tabs[0].Name(), "Created (2 of 312)")
+	}
+}
+
 func TestBuildTabs_DefaultTabsOnly(t *testing.T) {
 	grouped := &GroupedPullRequests{
 		Created:         gh.SearchResult[pullRequest]{TotalCount: 1, Items: []pullRequest{{Number: 1}}},
@@ -93,6 +117,53 @@ func TestBuildTabs_CustomTabNameIncludesCount(t *testing.T) {
 	}
 }
 
+func TestBuildTabs_ErrorTab(t *testing.T) {
+	ghResult := &gh.PRSearchResult{
+		Created:      []gh.PRSearchNode{{Number: 1}},
+		Participated: []gh.PRSearchNode{{Number: 2}},
+		Custom:       map[string][]gh.PRSearchNode{},
+		Errors: map[string]error{
+			"needs-triage": errors.New("invalid query"),
+			"participated": errors.New("HTTP 502"),
+		},
+	}
+	ghResult.Custom["needs-triage"] = nil
+
+	tabs := NewGroupedPullRequests(ghResult, "").BuildTabs()
+
+	if tabs[0].Err() != nil {
+		t.Errorf("tabs[0].Err() = %v, want nil", tabs[0].Err())
+	}
+	if got, want := tabs[1].Name(), "Participated (1, error: HTTP 502)"; got != want {
+		t.Errorf("tabs[1].Name() = %q, want %q", got, want)
+	}
+	if got, want := tabs[4].Name(), "Needs Triage (error: invalid query)"; got != want {
+		t.Errorf("tabs[4].Name() = %q, want %q", got, want)
+	}
+	if tabs[4].Err() == nil {
+		t.Error("tabs[4].Err() = nil, want the search error")
+	}
+}
+
+func TestBuildTabs_FailedCustomSearchGetsTab(t *testing.T) {
+	grouped := NewGroupedPullRequests(&gh.PRSearchResult{
+		Custom: map[string][]gh.PRSearchNode{},
+		Errors: map[string]error{"needsTriage": errors.New("invalid query")},
+	}, "")
+
+	tabs := grouped.BuildTabs()
+
+	if len(tabs) != 5 {
+		t.Fatalf("BuildTabs() returned %d tabs, want the failed custom tab after the default ones", len(tabs))
+	}
+	if got, want := tabs[4].Name(), "NeedsTriage (error: invalid query)"; got != want {
+		t.Errorf("tabs[4].Name() = %q, want %q", got, want)
+	}
+	if warnings := grouped.Warnings(); len(warnings) != 1 || warnings[0] != "NeedsTriage: invalid query" {
+		t.Errorf("Warnings() = %v, want the failed custom tab", warnings)
+	}
+}
+
 func TestPullRequest_RepositoryFullName(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -149,6 +220,49 @@ func TestPullRequest_RepositoryFullName(t *testing.T) {
 	}
 }
 
+func TestPullRequest_RepositoryDisplayName(t *testing.T) {
+	tests := []struct {
+		name    string
+		htmlURL string
+		want    string
+	}{
+		{
+			name:    "github.com omits host",
+			htmlURL: "https://github.com/owner/repo/pull/1",
+			want:    "owner/repo",
+		},
+		{
+			name:    "enterprise host is prefixed",
+			htmlURL: "https://ghe.example.com/owner/repo/pull/1",
+			want:    "ghe.example.com/owner/repo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &pullRequest{
+				RepositoryURL: "https://api.github.com/repos/owner/repo",
+				HTMLURL:       tt.htmlURL,
+			}
+			if got := pr.repositoryDisplayName(); got != tt.want {
+				t.Errorf("repositoryDisplayName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupedPullRequests_LoginFor(t *testing.T) {
+	o := &GroupedPullRequests{currentLogin: "me"}
+	o = o.WithHostLogins(map[string]string{"ghe.example.com": "corp-me"})
+
+	if got := o.loginFor("ghe.example.com"); got != "corp-me" {
+		t.Errorf("loginFor(ghe.example.com) = %q, want %q", got, "corp-me")
+	}
+	if got := o.loginFor("github.com"); got != "me" {
+		t.Errorf("loginFor(github.com) = %q, want %q", got, "me")
+	}
+}
+
 func TestPullRequest_ToItem_NoActivity(t *testing.T) {
 	pr := pullRequest{
 		Number:        42,
@@ -436,6 +550,20 @@ func TestFromGraphQL_PropagatesLatestActivity(t *testing.T) {
 	}
 }
 
+func TestFromGraphQL_MergeStatus(t *testing.T) {
+	node := gh.PRSearchNode{Number: 1, Title: "Test", Mergeable: "CONFLICTING", MergeStateStatus: "DIRTY"}
+	node.Repository.NameWithOwner = "owner/repo"
+
+	pr := fromGraphQL(node)
+
+	if pr.MergeStatus != mergestatus.MergeStatusConflicting {
+		t.Errorf("MergeStatus = %v, want %v", pr.MergeStatus, mergestatus.MergeStatusConflicting)
+	}
+	if got := pr.record()["mergeStatus"]; got != "conflicting" {
+		t.Errorf("record[mergeStatus] = %v, want conflicting", got)
+	}
+}
+
 func TestFromGraphQL(t *testing.T) {
 	node := gh.PRSearchNode{
 		Number:      123,
@@ -0,0 +1,105 @@
+package pr
+
+import (
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/snrsw/gh-own/internal/gh"
+	"github.com/snrsw/gh-own/internal/ui"
+)
+
+// ReviewersAction returns the action requesting and removing reviewers of the
+// selected or marked pull requests. It suggests the users who can be assigned
+// in the repository, who are its collaborators, and the teams of the current
+// user, as returned by teams, that belong to the owner of the repository.
+func ReviewersAction(client gh.ClientFunc, teams gh.TeamsFunc) ui.Action {
+	return ui.Action{
+		Key:     "E",
+		Help:    "reviewers",
+		Bulk:    true,
+		Applies: func(_ string, it ui.Item) bool { return actionable(it) },
+		Options: func(it ui.Item) (ui.Options, error) {
+			p := pullRequestOf(it)
+			c, err := client(p.host())
+			if err != nil {
+				return ui.Options{}, err
+			}
+			current, err := gh.GetReviewRequests(c, p.NodeID)
+			if err != nil {
+				return ui.Options{}, err
+			}
+			users, err := gh.GetAssignableUsers(c, p.repositoryFullName())
+			if err != nil {
+				return ui.Options{}, err
+			}
+			return ui.Options{All: reviewerSuggestions(p, users, teams), Selected: current}, nil
+		},
+		Run: func(it ui.Item, in ui.ActionInput) (ui.ActionResult, error) {
+			p := pullRequestOf(it)
+			c, err := client(p.host())
+			if err != nil {
+				return ui.ActionResult{}, err
+			}
+			if err := changeReviewers(c, p.NodeID, in.Added, in.Removed); err != nil {
+				return ui.ActionResult{}, err
+			}
+			return ui.ActionResult{Status: "Updated reviewers of " + p.reference()}, nil
+		},
+	}
+}
+
+// reviewerSuggestions returns users other than the author of p, followed by
+// the teams of the owner of its repository.
+func reviewerSuggestions(p pullRequest, users []string, teams gh.TeamsFunc) []string {
+	var suggestions []string
+	for _, u := range users {
+		if u != p.User.Login {
+			suggestions = append(suggestions, u)
+		}
+	}
+	// Teams only add to the suggestions, so failing to list them, e.g. for a
+	// token without the read:org scope, is not an error.
+	slugs, err := teams(p.host())
+	if err != nil {
+		slog.Debug("failed to list teams", "host", p.host(), "error", err)
+	}
+	owner, _, _ := strings.Cut(p.repositoryFullName(), "/")
+	for _, slug := range slugs {
+		if strings.HasPrefix(slug, owner+"/") {
+			suggestions = append(suggestions, slug)
+		}
+	}
+	return suggestions
+}
+
+// changeReviewers requests reviews from add and removes the review requests
+// of remove, given as logins or team slugs, on the pull request with node ID
+// prID.
+func changeReviewers(c *api.GraphQLClient, prID string, add, remove []string) error {
+	if len(remove) == 0 {
+		reviewers, err := gh.GetReviewers(c, add)
+		if err != nil {
+			return err
+		}
+		return gh.RequestReviews(c, prID, reviewers)
+	}
+
+	// GitHub removes review requests only by replacing the whole set.
+	current, err := gh.GetReviewRequests(c, prID)
+	if err != nil {
+		return err
+	}
+	var names []string
+	for _, name := range append(current, add...) {
+		if !slices.Contains(remove, name) && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	reviewers, err := gh.GetReviewers(c, names)
+	if err != nil {
+		return err
+	}
+	return gh.SetReviewers(c, prID, reviewers)
+}
@@ -0,0 +1,99 @@
+package pr
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/snrsw/gh-own/internal/cistatus"
+	"github.com/snrsw/gh-own/internal/gh"
+	"github.com/snrsw/gh-own/internal/ghtest"
+	"github.com/snrsw/gh-own/internal/ui"
+)
+
+func staticTeams(slugs ...string) gh.TeamsFunc {
+	return func(string) ([]string, error) { return slugs, nil }
+}
+
+func TestReviewersAction_Options(t *testing.T) {
+	fake := ghtest.NewGitHub(t,
+		`{"data":{"node":{"reviewRequests":{"nodes":[{"requestedReviewer":{"login":"bob"}}]}}}}`,
+		`{"data":{"repository":{"assignableUsers":{"nodes":[{"login":"alice"},{"login":"bob"},{"login":"octocat"}]}}}}`,
+	)
+	action := ReviewersAction(fake.Client, staticTeams("owner/core", "other/web"))
+	it := testItem(pullRequest{
+		NodeID:        "PR_7",
+		Number:        7,
+		User:          gh.User{Login: "octocat"},
+		RepositoryURL: "https://api.github.com/repos/owner/repo",
+	})
+
+	got, err := action.Options(it)
+	if err != nil {
+		t.Fatalf("Options() error: %v", err)
+	}
+	want := ui.Options{All: []string{"alice", "bob", "owner/core"}, Selected: []string{"bob"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Options() = %+v, want %+v without the author and teams of other owners", got, want)
+	}
+}
+
+func TestReviewersAction_OptionsWithoutTeams(t *testing.T) {
+	fake := ghtest.NewGitHub(t,
+		`{"data":{"node":{"reviewRequests":{"nodes":[]}}}}`,
+		`{"data":{"repository":{"assignableUsers":{"nodes":[{"login":"alice"}]}}}}`,
+	)
+	teams := func(string) ([]string, error) { return nil, errors.New("missing read:org scope") }
+	action := ReviewersAction(fake.Client, teams)
+
+	got, err := action.Options(mergeTestItem(cistatus.CIStatusSuccess))
+	if err != nil {
+		t.Fatalf("Options() error: %v", err)
+	}
+	if !reflect.DeepEqual(got.All, []string{"alice"}) {
+		t.Errorf("All = %v, want the users when teams cannot be listed", got.All)
+	}
+}
+
+func TestReviewersAction_Request(t *testing.T) {
+	fake := ghtest.NewGitHub(t,
+		`{"data":{"user":{"id":"U_1"}}}`,
+		`{"data":{"organization":{"team":{"id":"T_1"}}}}`,
+		`{"data":{"requestReviews":{"pullRequest":{"number":7}}}}`,
+	)
+	action := ReviewersAction(fake.Client, staticTeams())
+
+	result, err := action.Run(mergeTestItem(cistatus.CIStatusSuccess), ui.ActionInput{Added: []string{"alice", "owner/core"}})
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if result.Status != "Updated reviewers of owner/repo#7" {
+		t.Errorf("Status = %q", result.Status)
+	}
+	if len(fake.Requests) != 3 || !strings.Contains(fake.Requests[2].Query, "union: true") {
+		t.Errorf("requests = %v, want two lookups and requestReviews keeping the current reviewers", fake.Requests)
+	}
+}
+
+func TestReviewersAction_Remove(t *testing.T) {
+	fake := ghtest.NewGitHub(t,
+		`{"data":{"node":{"reviewRequests":{"nodes":[{"requestedReviewer":{"login":"bob"}},{"requestedReviewer":{"login":"carol"}}]}}}}`,
+		`{"data":{"user":{"id":"U_3"}}}`,
+		`{"data":{"requestReviews":{"pullRequest":{"number":7}}}}`,
+	)
+	action := ReviewersAction(fake.Client, staticTeams())
+
+	if _, err := action.Run(mergeTestItem(cistatus.CIStatusSuccess), ui.ActionInput{Removed: []string{"bob"}}); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if len(fake.Requests) != 3 {
+		t.Fatalf("made %d requests, want the current reviewers, a lookup and requestReviews", len(fake.Requests))
+	}
+	if login := fake.Requests[1].Variables["login"]; login != "carol" {
+		t.Errorf("looked up %v, want only carol to stay requested", login)
+	}
+	if !strings.Contains(fake.Requests[2].Query, "union: false") {
+		t.Error("removing a reviewer should replace the requested reviewers")
+	}
+}
@@ -0,0 +1,86 @@
+package pr
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/snrsw/gh-own/internal/cistatus"
+	"github.com/snrsw/gh-own/internal/reviewstatus"
+	"github.com/snrsw/gh-own/internal/sortmode"
+	"github.com/snrsw/gh-own/internal/ui"
+)
+
+// ciRank orders CI statuses so that those needing attention come first.
+var ciRank = map[cistatus.CIStatus]int{
+	cistatus.CIStatusFailure: 0,
+	cistatus.CIStatusPending: 1,
+	cistatus.CIStatusSuccess: 2,
+	cistatus.CIStatusNone:    3,
+}
+
+// reviewRank orders review statuses so that those needing attention come first.
+var reviewRank = map[reviewstatus.ReviewStatus]int{
+	reviewstatus.ReviewStatusChangesRequested: 0,
+	reviewstatus.ReviewStatusReviewRequired:   1,
+	reviewstatus.ReviewStatusApproved:         2,
+	reviewstatus.ReviewStatusNone:             3,
+}
+
+// activityAt returns when the latest comment, review or push happened,
+// falling back to the last update.
+func (p pullRequest) activityAt() string {
+	if p.LatestActivity.At != "" {
+		return p.LatestActivity.At
+	}
+	return p.UpdatedAt
+}
+
+// comparePullRequests returns the comparison of mode. Times and numbers sort
+// newest first, repositories alphabetically and statuses by what needs
+// attention first.
+func comparePullRequests(mode sortmode.SortMode) func(a, b pullRequest) int {
+	switch mode {
+	case sortmode.SortModeUpdated:
+		return func(a, b pullRequest) int { return cmp.Compare(b.UpdatedAt, a.UpdatedAt) }
+	case sortmode.SortModeCreated:
+		return func(a, b pullRequest) int { return cmp.Compare(b.CreatedAt, a.CreatedAt) }
+	case sortmode.SortModeActivity:
+		return func(a, b pullRequest) int { return cmp.Compare(b.activityAt(), a.activityAt()) }
+	case sortmode.SortModeRepo:
+		return func(a, b pullRequest) int {
+			return cmp.Or(cmp.Compare(a.repositoryDisplayName(), b.repositoryDisplayName()), cmp.Compare(b.Number, a.Number))
+		}
+	case sortmode.SortModeCI:
+		return func(a, b pullRequest) int { return cmp.Compare(ciRank[a.CIStatus], ciRank[b.CIStatus]) }
+	case sortmode.SortModeReview:
+		return func(a, b pullRequest) int { return cmp.Compare(reviewRank[a.ReviewStatus], reviewRank[b.ReviewStatus]) }
+	case sortmode.SortModeNumber:
+		return func(a, b pullRequest) int { return cmp.Compare(b.Number, a.Number) }
+	default:
+		return func(pullRequest, pullRequest) int { return 0 }
+	}
+}
+
+// sortPullRequests returns a copy of prs sorted by mode, keeping the search
+// order among equal pull requests.
+func sortPullRequests(prs []pullRequest, mode sortmode.SortMode) []pullRequest {
+	if mode == sortmode.SortModeNone {
+		return prs
+	}
+	sorted := slices.Clone(prs)
+	slices.SortStableFunc(sorted, comparePullRequests(mode))
+	return sorted
+}
+
+// tabSorts returns the sorts a pull request tab can be cycled through with "s".
+func tabSorts() []ui.Sort {
+	sorts := make([]ui.Sort, len(sortmode.PRModes))
+	for i, mode := range sortmode.PRModes {
+		compare := comparePullRequests(mode)
+		sorts[i] = ui.Sort{
+			Name:    string(mode),
+			Compare: func(a, b ui.Item) int { return compare(pullRequestOf(a), pullRequestOf(b)) },
+		}
+	}
+	return sorts
+}
@@ -0,0 +1,106 @@
+package pr
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/snrsw/gh-own/internal/cistatus"
+	"github.com/snrsw/gh-own/internal/gh"
+	"github.com/snrsw/gh-own/internal/reviewstatus"
+	"github.com/snrsw/gh-own/internal/sortmode"
+)
+
+func numbers(prs []pullRequest) []int {
+	ns := make([]int, len(prs))
+	for i, p := range prs {
+		ns[i] = p.Number
+	}
+	return ns
+}
+
+func TestSortPullRequests(t *testing.T) {
+	prs := []pullRequest{
+		{
+			Number:         1,
+			RepositoryURL:  "https://api.github.com/repos/owner/b",
+			UpdatedAt:      "2024-01-03T00:00:00Z",
+			CreatedAt:      "2024-01-01T00:00:00Z",
+			CIStatus:       cistatus.CIStatusSuccess,
+			ReviewStatus:   reviewstatus.ReviewStatusApproved,
+			LatestActivity: gh.LatestActivity{At: "2024-01-02T00:00:00Z"},
+		},
+		{
+			Number:        3,
+			RepositoryURL: "https://api.github.com/repos/owner/a",
+			UpdatedAt:     "2024-01-01T00:00:00Z",
+			CreatedAt:     "2024-01-02T00:00:00Z",
+			CIStatus:      cistatus.CIStatusFailure,
+			ReviewStatus:  reviewstatus.ReviewStatusNone,
+		},
+		{
+			Number:         2,
+			RepositoryURL:  "https://api.github.com/repos/owner/b",
+			UpdatedAt:      "2024-01-02T00:00:00Z",
+			CreatedAt:      "2024-01-03T00:00:00Z",
+			CIStatus:       cistatus.CIStatusPending,
+			ReviewStatus:   reviewstatus.ReviewStatusChangesRequested,
+			LatestActivity: gh.LatestActivity{At: "2024-01-04T00:00:00Z"},
+		},
+	}
+
+	tests := []struct {
+		mode sortmode.SortMode
+		want []int
+	}{
+		{sortmode.SortModeNone, []int{1, 3, 2}},
+		{sortmode.SortModeUpdated, []int{1, 2, 3}},
+		{sortmode.SortModeCreated, []int{2, 3, 1}},
+		{sortmode.SortModeActivity, []int{2, 1, 3}},
+		{sortmode.SortModeRepo, []int{3, 2, 1}},
+		{sortmode.SortModeCI, []int{3, 2, 1}},
+		{sortmode.SortModeReview, []int{2, 1, 3}},
+		{sortmode.SortModeNumber, []int{3, 2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			if got := numbers(sortPullRequests(prs, tt.mode)); !slices.Equal(got, tt.want) {
+				t.Errorf("sortPullRequests(%q) = %v, want %v", tt.mode, got, tt.want)
+			}
+		})
+	}
+	if got := numbers(prs); !slices.Equal(got, []int{1, 3, 2}) {
+		t.Errorf("input = %v, want it left in search order", got)
+	}
+}
+
+func TestBuildTabs_AppliesSort(t *testing.T) {
+	grouped := (&GroupedPullRequests{
+		Created: gh.SearchResult[pullRequest]{TotalCount: 2, Items: []pullRequest{{Number: 1}, {Number: 2}}},
+	}).WithSort(map[string]sortmode.SortMode{"created": sortmode.SortModeNumber})
+
+	tabs := grouped.BuildTabs()
+
+	if got := tabs[0].SortName(); got != "number" {
+		t.Errorf("tabs[0].SortName() = %q, want %q", got, "number")
+	}
+	if got := tabs[1].SortName(); got != "" {
+		t.Errorf("tabs[1].SortName() = %q, want the search order", got)
+	}
+	if got := numbers(grouped.Created.Items); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Created = %v, want BuildTabs to leave the results in search order", got)
+	}
+}
+
+func TestExportAndTable_ApplySort(t *testing.T) {
+	grouped := (&GroupedPullRequests{
+		Created: gh.SearchResult[pullRequest]{TotalCount: 2, Items: []pullRequest{{Number: 1}, {Number: 2}}},
+	}).WithSort(map[string]sortmode.SortMode{"created": sortmode.SortModeNumber})
+
+	records := grouped.Export()[0].Records
+	if len(records) != 2 || records[0]["number"] != 2 {
+		t.Errorf("Export() records = %v, want the configured sort", records)
+	}
+	if rows := grouped.Table().Rows; len(rows) != 2 || rows[0][2] != "#2" {
+		t.Errorf("Table() rows = %v, want the configured sort", rows)
+	}
+}
@@ -3,37 +3,77 @@ package pr
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/snrsw/gh-own/internal/cistatus"
 	"github.com/snrsw/gh-own/internal/gh"
+	"github.com/snrsw/gh-own/internal/mergestatus"
 	"github.com/snrsw/gh-own/internal/reviewstatus"
 	"github.com/snrsw/gh-own/internal/ui"
 )
 
 // BuildTabs converts grouped pull requests into UI tabs.
 func (o *GroupedPullRequests) BuildTabs() []ui.Tab {
-	tabs := []ui.Tab{
-		ui.NewTab(fmt.Sprintf("Created (%d)", o.Created.TotalCount), ui.CreateList(o.prItems(o.Created))),
-		ui.NewTab(fmt.Sprintf("Participated (%d)", o.Participated.TotalCount), ui.CreateList(o.prItems(o.Participated))),
-		ui.NewTab(fmt.Sprintf("Assigned (%d)", o.Assigned.TotalCount), ui.CreateList(o.prItems(o.Assigned))),
-		ui.NewTab(fmt.Sprintf("Review Requested (%d)", o.ReviewRequested.TotalCount), ui.CreateList(o.prItems(o.ReviewRequested))),
+	groups := o.tabGroups()
+	tabs := make([]ui.Tab, 0, len(groups))
+	for _, g := range groups {
+		mode := o.sort[g.key]
+		tab := ui.NewTab(g.name, ui.CreateList(o.prItems(g.result))).WithKey(g.key).WithError(g.err).WithClosed(o.closedTabs[g.key])
+		tabs = append(tabs, tab.WithTitle(g.result.TotalCount, g.title).WithSorts(tabSorts(), string(mode)))
+	}
+	return tabs
+}
+
+type tabGroup struct {
+	key    string
+	name   string
+	result gh.SearchResult[pullRequest]
+	err    error
+}
+
+// title returns the tab title for shown of total matches.
+func (g tabGroup) title(shown, total int) string {
+	if g.err != nil {
+		return ui.ErrorTabTitle(g.name, shown, gh.ErrorSummary(g.err))
+	}
+	return ui.TabTitle(g.name, shown, total)
+}
+
+// tabGroups returns the default groups followed by custom groups sorted by key.
+func (o *GroupedPullRequests) tabGroups() []tabGroup {
+	groups := []tabGroup{
+		{key: "created", name: "Created", result: o.Created},
+		{key: "participated", name: "Participated", result: o.Participated},
+		{key: "assigned", name: "Assigned", result: o.Assigned},
+		{key: "reviewRequested", name: "Review Requested", result: o.ReviewRequested},
 	}
 
 	keys := make([]string, 0, len(o.Custom))
 	for k := range o.Custom {
 		keys = append(keys, k)
 	}
+	// A custom search that failed has no results, but still gets its tab to
+	// show the error.
+	for k := range o.Errors {
+		_, ok := o.Custom[k]
+		if !ok && !slices.ContainsFunc(groups, func(g tabGroup) bool { return g.key == k }) {
+			keys = append(keys, k)
+		}
+	}
 	sort.Strings(keys)
 
 	for _, k := range keys {
-		sr := o.Custom[k]
-		tabs = append(tabs, ui.NewTab(fmt.Sprintf("%s (%d)", ui.HumanizeTabName(k), sr.TotalCount), ui.CreateList(o.prItems(sr))))
+		groups = append(groups, tabGroup{key: k, name: ui.HumanizeTabName(k), result: o.Custom[k]})
 	}
 
-	return tabs
+	for i := range groups {
+		groups[i].err = o.Errors[groups[i].key]
+	}
+
+	return groups
 }
 
 func (p pullRequest) toItem(currentLogin string) ui.Item {
@@ -55,25 +95,31 @@ func (p pullRequest) toItem(currentLogin string) ui.Item {
 			ui.UpdatedAgo(p.UpdatedAt),
 		)
 	}
-	titleText := RenderPRNumber(p.Number, p.Draft) + " " + p.Title
-
 	suffix := " " + cistatus.RenderCIStatus(p.CIStatus)
 	if rs := reviewstatus.RenderReviewStatus(p.ReviewStatus); rs != "" {
 		suffix = " " + rs + suffix
 	}
+	if ms := mergestatus.RenderMergeStatus(p.MergeStatus); ms != "" {
+		suffix = " " + ms + suffix
+	}
 
 	return ui.NewItem(
-		p.repositoryFullName(),
-		titleText,
+		p.repositoryDisplayName(),
+		p.titleText(),
 		desc,
 		p.HTMLURL,
-	).WithSuffix(suffix)
+	).WithSuffix(suffix).WithData(p)
+}
+
+// titleText renders the number, grey for drafts, and title of the pull request.
+func (p pullRequest) titleText() string {
+	return RenderPRNumber(p.Number, p.Draft) + " " + p.Title
 }
 
 func (o *GroupedPullRequests) prItems(prs gh.SearchResult[pullRequest]) []list.Item {
 	items := make([]list.Item, 0, len(prs.Items))
 	for _, pr := range prs.Items {
-		items = append(items, pr.toItem(o.currentLogin))
+		items = append(items, pr.toItem(o.loginFor(pr.host())))
 	}
 	return items
 }
@@ -0,0 +1,52 @@
+// Package sortmode defines the orders the items of a tab can be sorted in.
+package sortmode
+
+import (
+	"fmt"
+	"slices"
+)
+
+type SortMode string
+
+const (
+	// SortModeNone keeps the order the search returned.
+	SortModeNone    SortMode = ""
+	SortModeUpdated SortMode = "updated"
+	SortModeCreated SortMode = "created"
+	// SortModeActivity sorts by the time of the latest comment, review or push.
+	SortModeActivity SortMode = "activity"
+	SortModeRepo     SortMode = "repo"
+	SortModeCI       SortMode = "ci"
+	SortModeReview   SortMode = "review"
+	SortModeNumber   SortMode = "number"
+)
+
+// PRModes are the sort modes of pull request tabs, in the order they are
+// cycled through.
+var PRModes = []SortMode{
+	SortModeUpdated,
+	SortModeCreated,
+	SortModeActivity,
+	SortModeRepo,
+	SortModeCI,
+	SortModeReview,
+	SortModeNumber,
+}
+
+// IssueModes are the sort modes of issue tabs, which have no CI or review
+// status, in the order they are cycled through.
+var IssueModes = []SortMode{
+	SortModeUpdated,
+	SortModeCreated,
+	SortModeActivity,
+	SortModeRepo,
+	SortModeNumber,
+}
+
+// Parse returns the sort mode named s, which must be one of modes.
+func Parse(s string, modes []SortMode) (SortMode, error) {
+	if mode := SortMode(s); slices.Contains(modes, mode) {
+		return mode, nil
+	}
+	return SortModeNone, fmt.Errorf("unknown sort mode %q, want one of %v", s, modes)
+}
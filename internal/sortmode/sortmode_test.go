@@ -0,0 +1,27 @@
+package sortmode
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		modes   []SortMode
+		want    SortMode
+		wantErr bool
+	}{
+		{"updated", PRModes, SortModeUpdated, false},
+		{"ci", PRModes, SortModeCI, false},
+		{"ci", IssueModes, SortModeNone, true},
+		{"Updated", PRModes, SortModeNone, true},
+		{"", PRModes, SortModeNone, true},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.input, tt.modes)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Parse(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,485 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Action is an operation on the selected item, started with a key. Before it
+// runs, the user may pick one of its choices and then enter text.
+type Action struct {
+	// Key starts the action, e.g. "R".
+	Key string
+	// Help describes the action in the help line.
+	Help string
+	// Applies reports whether the action is offered for it in the tab with
+	// the given key. Nil offers it for every item.
+	Applies func(tab string, it Item) bool
+	// Choices returns the options picked from before the action runs. Nil
+	// skips the picker.
+	Choices func(it Item) []string
+	// Options fetches the options of a picker any number of which are picked
+	// at once, such as the labels of a repository. It is called outside the
+	// UI loop, and replaces Choices when set.
+	Options func(it Item) (Options, error)
+	// Compose returns the text a message is composed from, such as a quote of
+	// the comment replied to. It is called outside the UI loop. The message is
+	// edited in the editor set with WithEditor, or inline, and passed to Run
+	// as the text of the input.
+	Compose func(it Item) (string, error)
+	// Prompt returns the text input asked for once choice was picked. A zero
+	// Prompt runs the action without asking.
+	Prompt func(it Item, choice string) Prompt
+	// Run performs the action. It is called outside the UI loop.
+	Run func(it Item, in ActionInput) (ActionResult, error)
+	// Bulk offers the action for marked items, which it then runs on in turn
+	// with the choice and text entered once. Choices and Prompt are given the
+	// first marked item. Since Options are those of one repository, actions
+	// with Options are only offered for marked items of the same repository.
+	Bulk bool
+}
+
+// Prompt describes a text input asked for before an action runs.
+type Prompt struct {
+	Label string
+	// Required rejects empty input.
+	Required bool
+}
+
+// ActionInput is what the user picked and entered for an action.
+type ActionInput struct {
+	Choice string
+	Text   string
+	// Added and Removed are the options picked and unpicked, compared to
+	// Options.Selected.
+	Added   []string
+	Removed []string
+}
+
+// List splits Text into its comma-separated values, such as labels.
+func (in ActionInput) List() []string {
+	var values []string
+	for _, v := range strings.Split(in.Text, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// ActionResult is the outcome of an action that succeeded.
+type ActionResult struct {
+	// Status is shown in the status bar.
+	Status string
+	// Remove removes the item from every tab that lists only open items, e.g.
+	// once a pull request was merged.
+	Remove bool
+	// Open is a URL opened in the browser, e.g. the log of a failed check.
+	Open string
+	// Update returns the item as changed by the action, e.g. a pull request
+	// rendered as a draft, to replace it in every tab until the next refresh.
+	Update func(it Item) Item
+}
+
+// actionDoneMsg reports the outcome of a running action.
+type actionDoneMsg struct {
+	action Action
+	item   Item
+	result ActionResult
+	err    error
+}
+
+// bulkDoneMsg reports the outcome of a bulk action on each marked item.
+type bulkDoneMsg struct {
+	action Action
+	done   []actionDoneMsg
+}
+
+// pendingAction is an action waiting for the user to pick a choice or enter text.
+type pendingAction struct {
+	action Action
+	item   Item
+	// items are the marked items a bulk action runs on.
+	items   []Item
+	choices []string
+	cursor  int
+	choice  string
+	prompt  Prompt
+	input   textinput.Model
+	typing  bool
+	// loading is set while the options or the draft of a message are fetched.
+	loading bool
+	options optionSet
+	// draft is the text a message was composed from, and area edits it
+	// while composing.
+	draft     string
+	area      textarea.Model
+	composing bool
+}
+
+// WithActions returns a copy of the model offering actions on the selected item.
+func (m Model) WithActions(actions ...Action) Model {
+	m.actions = append(m.actions, actions...)
+	return m
+}
+
+// selectedItem returns the selected item of the active tab.
+func (m Model) selectedItem() (Item, bool) {
+	it, ok := m.tabs[m.activeTab].list.SelectedItem().(Item)
+	return it, ok
+}
+
+// actionTargets returns the items actions run on: the marked items if there
+// are any, and the selected item otherwise.
+func (m Model) actionTargets() (items []Item, bulk bool) {
+	if marked := m.markedItems(); len(marked) > 0 {
+		return marked, true
+	}
+	if it, ok := m.selectedItem(); ok {
+		return []Item{it}, false
+	}
+	return nil, false
+}
+
+// availableActions returns the actions offered for the action targets.
+func (m Model) availableActions() []Action {
+	items, bulk := m.actionTargets()
+	var available []Action
+	for _, a := range m.actions {
+		if len(items) > 0 && (a.Bulk || !bulk) && m.appliesToAll(a, items) && (a.Options == nil || sameRepo(items)) {
+			available = append(available, a)
+		}
+	}
+	return available
+}
+
+// appliesToAll reports whether a is offered for every one of items.
+func (m Model) appliesToAll(a Action, items []Item) bool {
+	if a.Applies == nil {
+		return true
+	}
+	for _, it := range items {
+		if !a.Applies(m.tabs[m.activeTab].key, it) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameRepo reports whether items all belong to the same repository.
+func sameRepo(items []Item) bool {
+	for _, it := range items[1:] {
+		if it.repoName != items[0].repoName {
+			return false
+		}
+	}
+	return true
+}
+
+// startAction begins the action bound to key, if one is offered for the
+// selected item.
+func (m Model) startAction(key string) (Model, tea.Cmd, bool) {
+	if m.tabs[m.activeTab].list.FilterState() == list.Filtering {
+		return m, nil, false
+	}
+	for _, a := range m.availableActions() {
+		if a.Key != key {
+			continue
+		}
+		items, _ := m.actionTargets()
+		it := items[0]
+		p := &pendingAction{action: a, item: it, items: items}
+		if a.Choices != nil {
+			p.choices = a.Choices(it)
+		}
+		m.pending = p
+		if a.Options != nil {
+			p.loading = true
+			return m, fetchOptions(p), true
+		}
+		if a.Compose != nil {
+			p.loading = true
+			return m, fetchDraft(p), true
+		}
+		if len(p.choices) == 0 {
+			return m.afterChoice("")
+		}
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+// afterChoice asks for text if the pending action needs it, and runs it otherwise.
+func (m Model) afterChoice(choice string) (Model, tea.Cmd, bool) {
+	p := m.pending
+	p.choice = choice
+	if p.action.Prompt != nil {
+		p.prompt = p.action.Prompt(p.item, choice)
+	}
+	if p.prompt.Label == "" {
+		m, cmd := m.runPending("")
+		return m, cmd, true
+	}
+	p.typing = true
+	p.input = textinput.New()
+	p.input.Prompt = p.prompt.Label + ": "
+	p.input.Width = max(10, m.outerW-lipgloss.Width(p.input.Prompt)-1)
+	return m, p.input.Focus(), true
+}
+
+// handlePendingKey routes a key to the picker or text input of the pending action.
+func (m Model) handlePendingKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	p := m.pending
+	if msg.String() == "esc" {
+		m.pending = nil
+		return m, nil
+	}
+
+	if p.loading {
+		return m, nil
+	}
+	if p.options.picked != nil {
+		return m.handleOptionsKey(msg)
+	}
+	if p.composing {
+		return m.handleComposeKey(msg)
+	}
+	if p.typing {
+		if msg.String() != "enter" {
+			var cmd tea.Cmd
+			p.input, cmd = p.input.Update(msg)
+			return m, cmd
+		}
+		text := strings.TrimSpace(p.input.Value())
+		if text == "" && p.prompt.Required {
+			p.input.Placeholder = "required"
+			return m, nil
+		}
+		return m.runPending(text)
+	}
+
+	switch msg.String() {
+	case "up", "k", "shift+tab":
+		p.cursor = (p.cursor - 1 + len(p.choices)) % len(p.choices)
+	case "down", "j", "tab":
+		p.cursor = (p.cursor + 1) % len(p.choices)
+	case "enter":
+		m, cmd, _ := m.afterChoice(p.choices[p.cursor])
+		return m, cmd
+	}
+	return m, nil
+}
+
+// runPending runs the pending action in the background.
+func (m Model) runPending(text string) (Model, tea.Cmd) {
+	p := m.pending
+	m.pending = nil
+	m.statusMsg = "→ " + p.action.Help + " " + p.subject() + "…"
+	in := ActionInput{Choice: p.choice, Text: text, Added: p.options.added(), Removed: p.options.removed()}
+	if len(p.items) > 1 {
+		return m, func() tea.Msg {
+			done := make([]actionDoneMsg, len(p.items))
+			for i, it := range p.items {
+				result, err := p.action.Run(it, in)
+				done[i] = actionDoneMsg{action: p.action, item: it, result: result, err: err}
+			}
+			return bulkDoneMsg{action: p.action, done: done}
+		}
+	}
+	return m, func() tea.Msg {
+		result, err := p.action.Run(p.item, in)
+		return actionDoneMsg{action: p.action, item: p.item, result: result, err: err}
+	}
+}
+
+// handleActionDone reports the outcome of an action in the status bar.
+func (m Model) handleActionDone(msg actionDoneMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.actionFailed(msg.action, msg.err)
+	}
+	clearCmd := tea.Tick(3*time.Second, func(time.Time) tea.Msg { return clearStatusMsg{} })
+	m.statusMsg = msg.result.Status
+	var cmd tea.Cmd
+	if msg.result.Remove {
+		m, cmd = m.removeItem(msg.item.url)
+	}
+	if msg.result.Update != nil {
+		cmd = tea.Batch(cmd, m.updateItem(msg.item.url, msg.result.Update))
+	}
+	if msg.result.Open != "" {
+		cmd = tea.Batch(cmd, openURLCmd(msg.result.Open))
+	}
+	m, detailCmd := m.syncDetail()
+	return m, tea.Batch(cmd, detailCmd, clearCmd)
+}
+
+// actionFailed reports in the status bar that action failed with err.
+func (m Model) actionFailed(action Action, err error) (Model, tea.Cmd) {
+	m.statusMsg = fmt.Sprintf("Failed to %s: %s", action.Help, strings.SplitN(err.Error(), "\n", 2)[0])
+	return m, tea.Tick(3*time.Second, func(time.Time) tea.Msg { return clearStatusMsg{} })
+}
+
+// handleBulkDone reports how many of the marked items a bulk action
+// succeeded on. Items it failed on stay marked, so it can be retried.
+func (m Model) handleBulkDone(msg bulkDoneMsg) (Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	var firstErr error
+	succeeded := make(map[string]bool, len(msg.done))
+	for _, d := range msg.done {
+		if d.err != nil {
+			if firstErr == nil {
+				firstErr = d.err
+			}
+			continue
+		}
+		succeeded[d.item.url] = true
+		if d.result.Remove {
+			var cmd tea.Cmd
+			m, cmd = m.removeItem(d.item.url)
+			cmds = append(cmds, cmd)
+		}
+		if d.result.Update != nil {
+			cmds = append(cmds, m.updateItem(d.item.url, d.result.Update))
+		}
+		if d.result.Open != "" {
+			cmds = append(cmds, openURLCmd(d.result.Open))
+		}
+	}
+	cmds = append(cmds, m.setMarked(succeeded, false))
+
+	m.statusMsg = fmt.Sprintf("%s: %d of %d done", msg.action.Help, len(succeeded), len(msg.done))
+	if firstErr != nil {
+		m.statusMsg += ", failed: " + strings.SplitN(firstErr.Error(), "\n", 2)[0]
+	}
+	m, detailCmd := m.syncDetail()
+	cmds = append(cmds, detailCmd, tea.Tick(3*time.Second, func(time.Time) tea.Msg { return clearStatusMsg{} }))
+	return m, tea.Batch(cmds...)
+}
+
+// removeItem removes the item with url from every tab that lists only open
+// items, counting it out of the tab titles.
+func (m Model) removeItem(url string) (Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	for i := range m.tabs {
+		t := &m.tabs[i]
+		if t.closed {
+			continue
+		}
+		items := t.flatItems()
+		kept := make([]list.Item, 0, len(items))
+		for _, li := range items {
+			if it, ok := li.(Item); ok && it.url == url {
+				continue
+			}
+			kept = append(kept, li)
+		}
+		if len(kept) == len(items) {
+			continue
+		}
+
+		cmds = append(cmds, t.setItems(kept))
+		if n := len(t.list.VisibleItems()); n > 0 && t.list.Index() >= n {
+			t.list.Select(n - 1)
+		}
+		if t.title != nil {
+			t.total = max(0, t.total-(len(items)-len(kept)))
+			t.name = t.title(len(kept), t.total)
+		}
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// updateItem replaces the item with url in every tab with update applied to it.
+func (m Model) updateItem(url string, update func(Item) Item) tea.Cmd {
+	var cmds []tea.Cmd
+	for i := range m.tabs {
+		t := &m.tabs[i]
+		items := t.flatItems()
+		updated := false
+		for j, li := range items {
+			if it, ok := li.(Item); ok && it.url == url {
+				items[j] = update(it)
+				updated = true
+			}
+		}
+		if updated {
+			cmds = append(cmds, t.setItems(items))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// subject describes what the pending action runs on.
+func (p *pendingAction) subject() string {
+	if len(p.items) > 1 {
+		return fmt.Sprintf("%d items", len(p.items))
+	}
+	return ansi.Strip(p.item.titleText)
+}
+
+// pendingView renders the choices of the pending action in place of the list.
+func (m Model) pendingView() string {
+	p := m.pending
+	var b strings.Builder
+	b.WriteString(StatusStyle.Render(p.action.Help+" "+p.subject()) + "\n\n")
+	switch {
+	case p.loading:
+		b.WriteString("Loading…\n")
+		return b.String()
+	case p.composing:
+		return b.String() + p.area.View()
+	}
+	lines := p.choices
+	if p.options.picked != nil {
+		lines = p.options.lines()
+	}
+	// Scroll long pickers, such as the labels of a repository, with the cursor.
+	start, end := 0, len(lines)
+	if visible := max(1, m.outerH-2); end > visible {
+		start = max(0, p.cursor-visible+1)
+		end = start + visible
+	}
+	for i := start; i < end; i++ {
+		if i == p.cursor {
+			b.WriteString(StatusStyle.Render("> "+lines[i]) + "\n")
+		} else {
+			b.WriteString("  " + lines[i] + "\n")
+		}
+	}
+	return b.String()
+}
+
+// pendingStatus renders the text input or the picker help of the pending action.
+func (m Model) pendingStatus() string {
+	switch {
+	case m.pending.typing:
+		return m.pending.input.View()
+	case m.pending.loading:
+		return renderHelp([]helpEntry{{"esc", "cancel"}})
+	case m.pending.composing:
+		return renderHelp([]helpEntry{
+			{"ctrl+s", "submit"},
+			{"esc", "cancel"},
+		})
+	case m.pending.options.picked != nil:
+		return renderHelp([]helpEntry{
+			{"↑/↓", "choose"},
+			{"space", "pick"},
+			{"enter", "apply"},
+			{"esc", "cancel"},
+		})
+	}
+	return renderHelp([]helpEntry{
+		{"↑/↓", "choose"},
+		{"enter", "confirm"},
+		{"esc", "cancel"},
+	})
+}
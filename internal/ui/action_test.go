@@ -0,0 +1,269 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// actionModel returns a sized model with one item in a tab keyed "created".
+func actionModel(t *testing.T, actions ...Action) Model {
+	t.Helper()
+	item := NewItem("owner/repo", "#12 Fix bug", "desc", "https://example.com/12").WithData(12)
+	m := NewModel([]Tab{NewTab("Created (1)", CreateList([]list.Item{item})).WithKey("created")}).WithActions(actions...)
+	newModel, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+	return m
+}
+
+func pressKey(t *testing.T, m Model, key string) (Model, tea.Cmd) {
+	t.Helper()
+	var msg tea.KeyMsg
+	switch key {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	case "down":
+		msg = tea.KeyMsg{Type: tea.KeyDown}
+	default:
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+	newModel, cmd := m.Update(msg)
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+	return m, cmd
+}
+
+func update(t *testing.T, m Model, msg tea.Msg) (Model, tea.Cmd) {
+	t.Helper()
+	newModel, cmd := m.Update(msg)
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+	return m, cmd
+}
+
+func TestModel_Action_PickChoiceAndEnterText(t *testing.T) {
+	var gotItem Item
+	var gotInput ActionInput
+	action := Action{
+		Key:     "R",
+		Help:    "review",
+		Choices: func(Item) []string { return []string{"approve", "comment"} },
+		Prompt: func(_ Item, choice string) Prompt {
+			return Prompt{Label: "Comment", Required: choice == "comment"}
+		},
+		Run: func(it Item, in ActionInput) (ActionResult, error) {
+			gotItem, gotInput = it, in
+			return ActionResult{Status: "Commented"}, nil
+		},
+	}
+	m := actionModel(t, action)
+
+	m, _ = pressKey(t, m, "R")
+	if m.pending == nil {
+		t.Fatal("'R' should start the action")
+	}
+	if view := m.View(); !strings.Contains(view, "approve") || !strings.Contains(view, "comment") {
+		t.Error("View() should list the choices")
+	}
+
+	m, _ = pressKey(t, m, "down")
+	m, _ = pressKey(t, m, "enter")
+	if m.pending == nil || !m.pending.typing {
+		t.Fatal("picking a choice with a prompt should ask for text")
+	}
+
+	m, cmd := pressKey(t, m, "enter")
+	if m.pending == nil || cmd != nil {
+		t.Fatal("empty text should be rejected for a required prompt")
+	}
+
+	m, _ = pressKey(t, m, "LGTM")
+	m, cmd = pressKey(t, m, "enter")
+	if m.pending != nil {
+		t.Error("submitting text should end the pending action")
+	}
+	if cmd == nil {
+		t.Fatal("submitting text should run the action")
+	}
+
+	msg := cmd()
+	if gotInput.Choice != "comment" || gotInput.Text != "LGTM" {
+		t.Errorf("input = %+v, want choice comment and text LGTM", gotInput)
+	}
+	if gotItem.Data() != 12 {
+		t.Errorf("item data = %v, want 12", gotItem.Data())
+	}
+
+	newModel, _ := m.Update(msg)
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+	if m.statusMsg != "Commented" {
+		t.Errorf("statusMsg = %q, want %q", m.statusMsg, "Commented")
+	}
+}
+
+func TestModel_Action_RunsWithoutChoicesOrPrompt(t *testing.T) {
+	ran := false
+	m := actionModel(t, Action{
+		Key:  "X",
+		Help: "close",
+		Run: func(Item, ActionInput) (ActionResult, error) {
+			ran = true
+			return ActionResult{}, nil
+		},
+	})
+
+	_, cmd := pressKey(t, m, "X")
+	if cmd == nil {
+		t.Fatal("an action without choices or prompt should run right away")
+	}
+	cmd()
+	if !ran {
+		t.Error("Run was not called")
+	}
+}
+
+func TestModel_Action_EscCancels(t *testing.T) {
+	m := actionModel(t, Action{
+		Key:     "R",
+		Help:    "review",
+		Choices: func(Item) []string { return []string{"approve"} },
+		Run: func(Item, ActionInput) (ActionResult, error) {
+			t.Error("Run should not be called after esc")
+			return ActionResult{}, nil
+		},
+	})
+
+	m, _ = pressKey(t, m, "R")
+	m, cmd := pressKey(t, m, "esc")
+	if m.pending != nil || cmd != nil {
+		t.Error("esc should cancel the pending action")
+	}
+}
+
+func TestModel_Action_NotOfferedWhenNotApplicable(t *testing.T) {
+	m := actionModel(t, Action{
+		Key:     "R",
+		Help:    "review",
+		Applies: func(tab string, _ Item) bool { return tab == "reviewRequested" },
+		Run: func(Item, ActionInput) (ActionResult, error) {
+			return ActionResult{}, nil
+		},
+	})
+
+	if strings.Contains(m.View(), "review") {
+		t.Error("help line should not list an action that does not apply")
+	}
+	m, cmd := pressKey(t, m, "R")
+	if m.pending != nil || cmd != nil {
+		t.Error("'R' should do nothing when the action does not apply")
+	}
+}
+
+func TestModel_Action_ShownInHelp(t *testing.T) {
+	m := actionModel(t, Action{
+		Key:  "R",
+		Help: "review",
+		Run: func(Item, ActionInput) (ActionResult, error) {
+			return ActionResult{}, nil
+		},
+	})
+
+	if !strings.Contains(m.View(), "R review") {
+		t.Error("help line should list the applicable action")
+	}
+}
+
+func TestModel_Action_ErrorShownInStatus(t *testing.T) {
+	m := actionModel(t)
+
+	newModel, _ := m.Update(actionDoneMsg{action: Action{Help: "review"}, err: errors.New("not allowed\ndetails")})
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+	if m.statusMsg != "Failed to review: not allowed" {
+		t.Errorf("statusMsg = %q, want %q", m.statusMsg, "Failed to review: not allowed")
+	}
+}
+
+func TestModel_Action_RemoveUpdatesTabTitles(t *testing.T) {
+	merged := NewItem("owner/repo", "#1 Merged", "", "https://example.com/1")
+	other := NewItem("owner/repo", "#2 Other", "", "https://example.com/2")
+	title := func(shown, total int) string { return TabTitle("Created", shown, total) }
+	m := NewModel([]Tab{
+		NewTab("", CreateList([]list.Item{merged, other})).WithTitle(10, title),
+		NewTab("", CreateList([]list.Item{other})).WithTitle(1, title),
+	})
+
+	newModel, _ := m.Update(actionDoneMsg{item: merged, result: ActionResult{Status: "Merged", Remove: true}})
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+
+	if n := len(m.tabs[0].list.Items()); n != 1 {
+		t.Errorf("first tab has %d items, want 1", n)
+	}
+	if m.tabs[0].Name() != "Created (1 of 9)" {
+		t.Errorf("first tab name = %q, want %q", m.tabs[0].Name(), "Created (1 of 9)")
+	}
+	if m.tabs[1].Name() != "Created (1)" {
+		t.Errorf("second tab name = %q, want it unchanged", m.tabs[1].Name())
+	}
+}
+
+func TestModel_Action_RemoveKeepsItemInClosedTabs(t *testing.T) {
+	closed := NewItem("owner/repo", "#1 Closed", "", "https://example.com/1")
+	m := NewModel([]Tab{
+		NewTab("Open", CreateList([]list.Item{closed})),
+		NewTab("All", CreateList([]list.Item{closed})).WithClosed(true),
+	})
+
+	m, _ = update(t, m, actionDoneMsg{item: closed, result: ActionResult{Status: "Closed", Remove: true}})
+
+	if n := len(m.tabs[0].list.Items()); n != 0 {
+		t.Errorf("open tab has %d items, want 0", n)
+	}
+	if n := len(m.tabs[1].list.Items()); n != 1 {
+		t.Errorf("closed tab has %d items, want the closed item kept", n)
+	}
+}
+
+func TestModel_Action_UpdatesItemInPlace(t *testing.T) {
+	m := actionModel(t, Action{
+		Key:  "D",
+		Help: "convert to draft",
+		Run: func(Item, ActionInput) (ActionResult, error) {
+			return ActionResult{Update: func(it Item) Item { return it.WithTitleText("#12 Draft").WithData(13) }}, nil
+		},
+	})
+
+	m, cmd := pressKey(t, m, "D")
+	newModel, _ := m.Update(cmd())
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+	it, _ := m.selectedItem()
+	if it.titleText != "#12 Draft" || it.Data() != 13 {
+		t.Errorf("item = %q with data %v, want it updated in place", it.titleText, it.Data())
+	}
+	if len(m.tabs[0].list.Items()) != 1 {
+		t.Error("updating should keep the item in the tab")
+	}
+}
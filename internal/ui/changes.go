@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// change marks how an item differs from the previous fetch.
+type change int
+
+const (
+	unchanged change = iota
+	// changeNew marks an item that was not in its tab before.
+	changeNew
+	// changeUpdated marks an item whose title suffix, which carries the CI and
+	// review status glyphs, changed.
+	changeUpdated
+)
+
+// markChanges returns items with the marks picked with space carried over from
+// prev, matching items by URL. With highlight, each is also marked with how it
+// differs from prev.
+func markChanges(prev, items []list.Item, highlight bool) []list.Item {
+	before := make(map[string]Item, len(prev))
+	for _, li := range prev {
+		if it, ok := li.(Item); ok {
+			before[it.url] = it
+		}
+	}
+
+	marked := make([]list.Item, len(items))
+	for i, li := range items {
+		it, ok := li.(Item)
+		if !ok {
+			marked[i] = li
+			continue
+		}
+		old, seen := before[it.url]
+		it.marked = old.marked
+		switch {
+		case !highlight:
+			it.change = unchanged
+		case !seen:
+			it.change = changeNew
+		case old.titleSuffix != it.titleSuffix:
+			it.change = changeUpdated
+		default:
+			it.change = unchanged
+		}
+		marked[i] = it
+	}
+	return marked
+}
+
+// carryOver swaps the items of tabs into the lists of prev, so that the cursor,
+// any filter, the sort picked with "s" and the sections survive a refresh, and
+// with highlight marks what changed. Tabs are matched by position; if the
+// number of tabs differs, tabs is returned as-is.
+func carryOver(prev, tabs []Tab, highlight bool) ([]Tab, tea.Cmd) {
+	if len(prev) != len(tabs) {
+		return tabs, nil
+	}
+
+	var cmds []tea.Cmd
+	for i := range tabs {
+		l := prev[i].list
+		selected := selectionKey(l.SelectedItem())
+
+		cmds = append(cmds, l.SetItems(markChanges(prev[i].flatItems(), tabs[i].list.Items(), highlight)))
+		tabs[i].list = l
+		tabs[i].sortName = prev[i].sortName
+		tabs[i].grouped = prev[i].grouped
+		tabs[i].collapsed = prev[i].collapsed
+		cmds = append(cmds, tabs[i].arrange())
+		tabs[i].selectKey(selected)
+	}
+	return tabs, tea.Batch(cmds...)
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+func changeItem(url, suffix string) Item {
+	return NewItem("owner/repo", "#1 Title", "desc", url).WithSuffix(suffix)
+}
+
+func TestMarkChanges(t *testing.T) {
+	prev := []list.Item{
+		changeItem("https://example.com/1", " ✓"),
+		changeItem("https://example.com/2", " ●"),
+	}
+	items := []list.Item{
+		changeItem("https://example.com/1", " ✓"),
+		changeItem("https://example.com/2", " ✗"),
+		changeItem("https://example.com/3", " ✓"),
+	}
+
+	got := markChanges(prev, items, true)
+
+	want := []change{unchanged, changeUpdated, changeNew}
+	for i, w := range want {
+		if it, ok := got[i].(Item); !ok || it.change != w {
+			t.Errorf("item %d change = %v, want %v", i, it.change, w)
+		}
+	}
+}
+
+func TestMarkChanges_WithoutHighlight(t *testing.T) {
+	old := changeItem("https://example.com/1", " ●")
+	old.marked = true
+	items := []list.Item{
+		changeItem("https://example.com/1", " ✓"),
+		changeItem("https://example.com/2", " ✓"),
+	}
+
+	got := markChanges([]list.Item{old}, items, false)
+
+	for i, li := range got {
+		if it, ok := li.(Item); !ok || it.change != unchanged {
+			t.Errorf("item %d change = %v, want unchanged", i, it.change)
+		}
+	}
+	if it, ok := got[0].(Item); !ok || !it.marked {
+		t.Error("the mark picked with space should be carried over")
+	}
+}
+
+func TestMarkChanges_ClearsPreviousMarks(t *testing.T) {
+	old := changeItem("https://example.com/1", " ✓")
+	old.change = changeNew
+
+	got := markChanges([]list.Item{old}, []list.Item{changeItem("https://example.com/1", " ✓")}, true)
+
+	if it, ok := got[0].(Item); !ok || it.change != unchanged {
+		t.Errorf("change = %v, want unchanged", it.change)
+	}
+}
+
+func TestCarryOver_KeepsSelectedItem(t *testing.T) {
+	prevList := CreateList([]list.Item{
+		changeItem("https://example.com/1", ""),
+		changeItem("https://example.com/2", ""),
+	})
+	prevList.SetSize(80, 20)
+	prevList.Select(1)
+	prev := []Tab{NewTab("Created (2)", prevList)}
+
+	// A new item is inserted before the selected one.
+	tabs := []Tab{NewTab("Created (3)", CreateList([]list.Item{
+		changeItem("https://example.com/3", ""),
+		changeItem("https://example.com/1", ""),
+		changeItem("https://example.com/2", ""),
+	}))}
+
+	got, _ := carryOver(prev, tabs, true)
+
+	if got[0].Name() != "Created (3)" {
+		t.Errorf("Name() = %q, want %q", got[0].Name(), "Created (3)")
+	}
+	sel, ok := got[0].list.SelectedItem().(Item)
+	if !ok || sel.url != "https://example.com/2" {
+		t.Errorf("selected item = %q, want https://example.com/2", sel.url)
+	}
+	if first, ok := got[0].list.Items()[0].(Item); !ok || first.change != changeNew {
+		t.Errorf("inserted item change = %v, want changeNew", first.change)
+	}
+}
+
+func TestCarryOver_TabCountMismatch(t *testing.T) {
+	prev := []Tab{NewTab("Created (0)", CreateList(nil))}
+	tabs := []Tab{
+		NewTab("Created (1)", CreateList([]list.Item{changeItem("https://example.com/1", "")})),
+		NewTab("Assigned (0)", CreateList(nil)),
+	}
+
+	got, _ := carryOver(prev, tabs, true)
+
+	if len(got) != 2 {
+		t.Fatalf("len(tabs) = %d, want 2", len(got))
+	}
+	if it, ok := got[0].list.Items()[0].(Item); !ok || it.change != unchanged {
+		t.Errorf("change = %v, want unchanged when tabs cannot be matched", it.change)
+	}
+}
@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// composeMsg delivers the text the message of a pending action is composed from.
+type composeMsg struct {
+	pending *pendingAction
+	text    string
+	err     error
+}
+
+// editedMsg reports that the editor composing the message of a pending
+// action exited.
+type editedMsg struct {
+	pending *pendingAction
+	path    string
+	err     error
+}
+
+// WithEditor returns a copy of the model composing messages, such as
+// comments, in editor rather than inline. The editor is run with the file to
+// edit appended, e.g. "vim" or "code --wait"; empty composes inline.
+func (m Model) WithEditor(editor string) Model {
+	m.editor = editor
+	return m
+}
+
+// fetchDraft fetches the text the message of the pending action p is composed from.
+func fetchDraft(p *pendingAction) tea.Cmd {
+	return func() tea.Msg {
+		text, err := p.action.Compose(p.item)
+		return composeMsg{pending: p, text: text, err: err}
+	}
+}
+
+// handleCompose opens the fetched draft of the pending action in the editor,
+// or in a text area if no editor is set.
+func (m Model) handleCompose(msg composeMsg) (Model, tea.Cmd) {
+	p := m.pending
+	if p != msg.pending {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.pending = nil
+		return m.actionFailed(p.action, msg.err)
+	}
+	p.draft = msg.text
+
+	if fields := strings.Fields(m.editor); len(fields) > 0 {
+		path, err := writeDraft(msg.text)
+		if err != nil {
+			m.pending = nil
+			return m.actionFailed(p.action, err)
+		}
+		cmd := exec.Command(fields[0], append(fields[1:], path)...)
+		return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+			return editedMsg{pending: p, path: path, err: err}
+		})
+	}
+
+	winH, winV := WindowStyle.GetFrameSize()
+	p.loading = false
+	p.composing = true
+	p.area = textarea.New()
+	p.area.ShowLineNumbers = false
+	p.area.CharLimit = 0
+	p.area.SetWidth(max(20, m.outerW-winH))
+	p.area.SetHeight(max(3, m.outerH-winV-2))
+	p.area.SetValue(msg.text)
+	return m, p.area.Focus()
+}
+
+// handleEdited runs the pending action with the message written in the editor.
+func (m Model) handleEdited(msg editedMsg) (Model, tea.Cmd) {
+	defer os.Remove(msg.path) //nolint:errcheck
+	p := m.pending
+	if p != msg.pending {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.pending = nil
+		return m.actionFailed(p.action, msg.err)
+	}
+	text, err := os.ReadFile(msg.path)
+	if err != nil {
+		m.pending = nil
+		return m.actionFailed(p.action, err)
+	}
+	return m.submitMessage(string(text))
+}
+
+// handleComposeKey edits the message of the pending action in the text area
+// and submits it with ctrl+s.
+func (m Model) handleComposeKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	p := m.pending
+	if msg.String() == "ctrl+s" {
+		return m.submitMessage(p.area.Value())
+	}
+	var cmd tea.Cmd
+	p.area, cmd = p.area.Update(msg)
+	return m, cmd
+}
+
+// submitMessage runs the pending action with text, unless it is empty or
+// was left as drafted, e.g. only quoting the comment replied to.
+func (m Model) submitMessage(text string) (Model, tea.Cmd) {
+	p := m.pending
+	text = strings.TrimSpace(text)
+	if text == "" || text == strings.TrimSpace(p.draft) {
+		m.pending = nil
+		m.statusMsg = "Discarded the unchanged " + p.action.Help
+		return m, nil
+	}
+	return m.runPending(text)
+}
+
+// writeDraft writes text to a temporary file for the editor to open.
+func writeDraft(text string) (string, error) {
+	f, err := os.CreateTemp("", "gh-own-*.md")
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.WriteString(text); err != nil {
+		f.Close()           //nolint:errcheck
+		os.Remove(f.Name()) //nolint:errcheck
+		return "", err
+	}
+	return f.Name(), f.Close()
+}
@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func composeAction(got *ActionInput) Action {
+	return Action{
+		Key:     "C",
+		Help:    "comment",
+		Compose: func(Item) (string, error) { return "> can you take a look?\n\n", nil },
+		Run: func(_ Item, in ActionInput) (ActionResult, error) {
+			*got = in
+			return ActionResult{Status: "Commented"}, nil
+		},
+	}
+}
+
+func TestModel_Action_ComposeInline(t *testing.T) {
+	var got ActionInput
+	m := actionModel(t, composeAction(&got))
+
+	m, cmd := pressKey(t, m, "C")
+	if m.pending == nil || !m.pending.loading || cmd == nil {
+		t.Fatal("'C' should fetch the draft")
+	}
+	m, _ = update(t, m, cmd())
+	if !m.pending.composing {
+		t.Fatal("without an editor the message should be composed inline")
+	}
+	if view := m.View(); !strings.Contains(view, "> can you take a look?") || !strings.Contains(view, "ctrl+s") {
+		t.Errorf("View() should show the draft and how to submit it, got:\n%s", view)
+	}
+
+	m, _ = pressKey(t, m, "Sure!")
+	m, cmd = update(t, m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.pending != nil || cmd == nil {
+		t.Fatal("ctrl+s should run the action")
+	}
+	cmd()
+	if got.Text != "> can you take a look?\n\nSure!" {
+		t.Errorf("Text = %q, want the quote followed by the reply", got.Text)
+	}
+}
+
+func TestModel_Action_ComposeUnchangedIsDiscarded(t *testing.T) {
+	var got ActionInput
+	m := actionModel(t, composeAction(&got))
+
+	m, cmd := pressKey(t, m, "C")
+	m, _ = update(t, m, cmd())
+	m, cmd = update(t, m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.pending != nil || cmd != nil {
+		t.Error("submitting the draft unchanged should not run the action")
+	}
+	if m.statusMsg != "Discarded the unchanged comment" {
+		t.Errorf("statusMsg = %q", m.statusMsg)
+	}
+}
+
+func TestModel_Action_ComposeInEditor(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	var got ActionInput
+	m := actionModel(t, composeAction(&got)).WithEditor("vi")
+
+	m, cmd := pressKey(t, m, "C")
+	m, cmd = update(t, m, cmd())
+	if m.pending == nil || m.pending.composing || cmd == nil {
+		t.Fatal("with an editor the message should be composed in it")
+	}
+
+	// Stand in for the editor, which the program would run.
+	f, err := os.CreateTemp(t.TempDir(), "draft-*.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString("> can you take a look?\n\nOn it.\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	m, cmd = update(t, m, editedMsg{pending: m.pending, path: f.Name()})
+	if m.pending != nil || cmd == nil {
+		t.Fatal("the edited message should run the action")
+	}
+	cmd()
+	if got.Text != "> can you take a look?\n\nOn it." {
+		t.Errorf("Text = %q, want the edited message", got.Text)
+	}
+	if _, err := os.Stat(f.Name()); !errors.Is(err, os.ErrNotExist) {
+		t.Error("the edited file should be removed")
+	}
+}
+
+func TestModel_Action_ComposeEditorFailed(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	var got ActionInput
+	m := actionModel(t, composeAction(&got)).WithEditor("vi")
+
+	m, cmd := pressKey(t, m, "C")
+	m, _ = update(t, m, cmd())
+	m, _ = update(t, m, editedMsg{pending: m.pending, err: errors.New("exit status 1")})
+	if m.pending != nil {
+		t.Error("a failed editor should end the pending action")
+	}
+	if m.statusMsg != "Failed to comment: exit status 1" {
+		t.Errorf("statusMsg = %q", m.statusMsg)
+	}
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/lipgloss"
@@ -12,18 +13,28 @@ import (
 type githubDelegate struct {
 	list.DefaultDelegate
 	repoNameStyle lipgloss.Style
+	sectionStyle  lipgloss.Style
+	// grouped leaves the repository out of items, which are listed below a
+	// section for it.
+	grouped bool
 }
 
 func (d githubDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
-	item, ok := listItem.(Item)
-	if !ok {
+	if m.Width() <= 0 {
 		return
 	}
-	if m.Width() <= 0 {
+	if s, ok := listItem.(section); ok {
+		d.renderSection(w, m, index, s)
+		return
+	}
+	item, ok := listItem.(Item)
+	if !ok {
 		return
 	}
 
-	repo  := ansi.Truncate(item.repoName,    m.Width(), "…")
+	badge := changeBadge(item.change)
+
+	repo  := ansi.Truncate(item.repoName,    m.Width()-lipgloss.Width(badge)-markWidth(item), "…")
 	title := ansi.Truncate(item.titleText,   m.Width(), "…")
 	desc  := ansi.Truncate(item.description, m.Width(), "…")
 
@@ -39,6 +50,19 @@ func (d githubDelegate) Render(w io.Writer, m list.Model, index int, listItem li
 		desc  = d.Styles.NormalDesc.Render(desc)
 	}
 
+	if d.grouped {
+		if item.marked {
+			title = MarkStyle.Render("◉ ") + title
+		}
+		fmt.Fprintf(w, "%s%s\n%s", title, badge, desc)
+		return
+	}
+
+	repo += badge
+	if item.marked {
+		repo = MarkStyle.Render("◉ ") + repo
+	}
+
 	if d.ShowDescription {
 		fmt.Fprintf(w, "%s\n%s\n%s", repo, title, desc)
 	} else {
@@ -46,6 +70,55 @@ func (d githubDelegate) Render(w io.Writer, m list.Model, index int, listItem li
 	}
 }
 
+// renderSection renders the header of the items of one repository, underlined
+// by a rule.
+func (d githubDelegate) renderSection(w io.Writer, m list.Model, index int, s section) {
+	glyph := "▾"
+	if s.collapsed {
+		glyph = "▸"
+	}
+	header := ansi.Truncate(fmt.Sprintf("%s %s (%d)", glyph, s.repo, s.count), m.Width(), "…")
+	rule := helpSepStyle.Render(strings.Repeat("─", lipgloss.Width(header)))
+	if index == m.Index() && m.FilterState() != list.Filtering {
+		header = d.Styles.SelectedTitle.Render(header)
+	} else {
+		header = d.sectionStyle.Render(header)
+	}
+	fmt.Fprintf(w, "%s\n%s", header, rule)
+}
+
+// withGrouped returns a copy of the delegate for a tab grouped by repository,
+// whose items take two lines instead of three.
+func (d githubDelegate) withGrouped(grouped bool) githubDelegate {
+	d.grouped = grouped
+	if grouped {
+		d.SetHeight(2)
+	} else {
+		d.SetHeight(3)
+	}
+	return d
+}
+
+// markWidth returns the width taken by the mark of a marked item.
+func markWidth(item Item) int {
+	if item.marked {
+		return 2
+	}
+	return 0
+}
+
+// changeBadge returns the marker shown after the repository name of an item
+// that is new or changed since the previous fetch.
+func changeBadge(c change) string {
+	switch c {
+	case changeNew:
+		return " " + NewBadgeStyle.Render("● new")
+	case changeUpdated:
+		return " " + UpdatedBadgeStyle.Render("● updated")
+	}
+	return ""
+}
+
 func newGithubDelegate() githubDelegate {
 	d := list.NewDefaultDelegate()
 
@@ -68,5 +141,8 @@ func newGithubDelegate() githubDelegate {
 		DefaultDelegate: d,
 		repoNameStyle: lipgloss.NewStyle().
 			Foreground(colorSecondary),
+		sectionStyle: lipgloss.NewStyle().
+			Foreground(colorSecondary).
+			Bold(true),
 	}
 }
@@ -94,3 +94,30 @@ func TestRender_TwoLines_NoDescription(t *testing.T) {
 		t.Errorf("Render() produced %d lines, want 2:\n%q", len(lines), buf.String())
 	}
 }
+
+func TestRender_ShowsChangeBadge(t *testing.T) {
+	d := newGithubDelegate()
+	d.ShowDescription = true
+
+	tests := []struct {
+		name   string
+		change change
+		want   string
+	}{
+		{"new", changeNew, "owner/repo ● new"},
+		{"updated", changeUpdated, "owner/repo ● updated"},
+		{"unchanged", unchanged, "owner/repo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := NewItem("owner/repo", "#1 Fix", "updated 2h ago", "https://example.com")
+			item.change = tt.change
+			m := list.New([]list.Item{item}, d, 80, 20)
+
+			lines := renderLines(t, d, m, 0, item)
+			if got := ansi.Strip(lines[0]); got != tt.want {
+				t.Errorf("repo line = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
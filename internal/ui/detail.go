@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"errors"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// detailSplitWidth is the narrowest window in which the detail pane is shown
+// next to the list rather than in place of it.
+const detailSplitWidth = 100
+
+// ErrNotFetched is returned by a DetailFunc for items from a result cache
+// written before the details could be fetched for them.
+var ErrNotFetched = errors.New("not available for cached results")
+
+// DetailFunc fetches the details of it. The returned function renders them
+// for a pane width, so each item is fetched only once.
+type DetailFunc func(it Item) (func(width int) string, error)
+
+// detailMsg carries the details fetched for the item with url.
+type detailMsg struct {
+	url    string
+	render func(width int) string
+	err    error
+}
+
+// detailPane shows the details of the selected item, fetched lazily.
+type detailPane struct {
+	fetch    DetailFunc
+	open     bool
+	fetched  map[string]detailMsg
+	loading  map[string]bool
+	viewport viewport.Model
+	// shown is the URL of the item rendered into the viewport; it is cleared
+	// when the pane is resized so the item is rendered again.
+	shown string
+}
+
+// WithDetail returns a copy of the model that shows the details of the
+// selected item, fetched with fetch, when v is pressed.
+func (m Model) WithDetail(fetch DetailFunc) Model {
+	m.detail = detailPane{
+		fetch:   fetch,
+		fetched: make(map[string]detailMsg),
+		loading: make(map[string]bool),
+	}
+	return m
+}
+
+// split reports whether the detail pane is shown next to the list.
+func (m Model) split() bool {
+	return m.detail.open && m.outerW >= detailSplitWidth
+}
+
+// toggleDetail opens or closes the detail pane.
+func (m Model) toggleDetail() (Model, tea.Cmd, bool) {
+	if m.detail.fetch == nil || m.tabs[m.activeTab].list.FilterState() == list.Filtering {
+		return m, nil, false
+	}
+	m.detail.open = !m.detail.open
+	m.detail.shown = ""
+	if m.width > 0 {
+		m = m.handleWindowSize(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	}
+	m, cmd := m.syncDetail()
+	return m, cmd, true
+}
+
+// syncDetail shows the details of the selected item in the pane, fetching
+// them if needed.
+func (m Model) syncDetail() (Model, tea.Cmd) {
+	if !m.detail.open {
+		return m, nil
+	}
+	it, ok := m.selectedItem()
+	if !ok {
+		m.detail.viewport.SetContent("")
+		m.detail.shown = ""
+		return m, nil
+	}
+
+	width := m.detail.viewport.Width
+	d, fetched := m.detail.fetched[it.url]
+	if !fetched {
+		m.detail.viewport.SetContent(InfoStyle.Render("Loading details…"))
+		m.detail.shown = ""
+		if m.detail.loading[it.url] {
+			return m, nil
+		}
+		m.detail.loading[it.url] = true
+		fetch := m.detail.fetch
+		return m, func() tea.Msg {
+			render, err := fetch(it)
+			return detailMsg{url: it.url, render: render, err: err}
+		}
+	}
+
+	if m.detail.shown == it.url {
+		return m, nil
+	}
+	if d.err != nil {
+		m.detail.viewport.SetContent(ErrorStyle.Width(width).Render(m.detailError(d.err)))
+	} else {
+		m.detail.viewport.SetContent(d.render(width))
+	}
+	m.detail.viewport.GotoTop()
+	m.detail.shown = it.url
+	return m, nil
+}
+
+// detailError describes why the details could not be loaded. For cached items
+// it tells how to get fresh ones, if the results can be refreshed at all.
+func (m Model) detailError(err error) string {
+	msg := "Failed to load details: " + err.Error()
+	if !errors.Is(err, ErrNotFetched) || m.fetchCmd == nil {
+		return msg
+	}
+	if m.loading || m.stale() || m.refreshing {
+		return msg + "; wait for the refresh to finish"
+	}
+	return msg + "; refresh with r"
+}
+
+// handleDetail stores fetched details and shows them if the item is still selected.
+func (m Model) handleDetail(msg detailMsg) (Model, tea.Cmd) {
+	delete(m.detail.loading, msg.url)
+	m.detail.fetched[msg.url] = msg
+	return m.syncDetail()
+}
+
+// resetDetail forgets fetched details, e.g. after a refresh.
+func (m Model) resetDetail() Model {
+	if m.detail.fetch == nil {
+		return m
+	}
+	m.detail.fetched = make(map[string]detailMsg)
+	m.detail.shown = ""
+	return m
+}
+
+// scrollDetail scrolls the detail pane by half a page.
+func (m Model) scrollDetail(down bool) (Model, tea.Cmd, bool) {
+	if !m.detail.open {
+		return m, nil, false
+	}
+	if down {
+		m.detail.viewport.HalfPageDown()
+	} else {
+		m.detail.viewport.HalfPageUp()
+	}
+	return m, nil, true
+}
+
+// sizeDetail sizes the detail pane for the inner window size and returns the
+// width left for the list.
+func (m Model) sizeDetail(innerW, innerH int) (Model, int) {
+	if !m.detail.open {
+		return m, innerW
+	}
+	m.detail.shown = ""
+	if !m.split() {
+		m.detail.viewport.Width = innerW
+		m.detail.viewport.Height = innerH
+		return m, innerW
+	}
+	listW := innerW * 45 / 100
+	m.detail.viewport.Width = innerW - listW - DetailStyle.GetHorizontalFrameSize()
+	m.detail.viewport.Height = innerH
+	return m, listW
+}
+
+// detailView renders the detail pane next to or in place of listView.
+func (m Model) detailView(listView string) string {
+	if !m.split() {
+		return m.detail.viewport.View()
+	}
+	l := m.tabs[m.activeTab].list
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(l.Width()).Render(listView),
+		DetailStyle.Render(m.detail.viewport.View()),
+	)
+}
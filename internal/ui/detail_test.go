@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// detailModel returns actionModel showing details rendered by fetch, sized to width.
+func detailModel(t *testing.T, width int, fetch DetailFunc) Model {
+	t.Helper()
+	m := actionModel(t).WithDetail(fetch)
+	newModel, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: 30})
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+	return m
+}
+
+func TestModel_Detail_FetchesOnceAndRenders(t *testing.T) {
+	calls := 0
+	fetch := func(it Item) (func(int) string, error) {
+		calls++
+		return func(int) string { return "details of " + it.url }, nil
+	}
+	m := detailModel(t, 140, fetch)
+
+	m, cmd := pressKey(t, m, "v")
+	if !m.detail.open || cmd == nil {
+		t.Fatal("'v' should open the detail pane and fetch the selected item")
+	}
+	if view := m.View(); !strings.Contains(view, "Loading details") {
+		t.Error("View() should show a loading note while fetching")
+	}
+
+	m, _ = update(t, m, cmd())
+	view := m.View()
+	if !strings.Contains(view, "details of https://example.com/12") {
+		t.Error("View() should show the fetched details")
+	}
+	if !strings.Contains(view, "Fix bug") {
+		t.Error("View() should keep the list next to the pane in a wide window")
+	}
+
+	m, _ = pressKey(t, m, "v")
+	m, cmd = pressKey(t, m, "v")
+	if cmd != nil || calls != 1 {
+		t.Errorf("reopening fetched %d times, want details reused", calls)
+	}
+
+	m, _ = pressKey(t, m, "v")
+	if m.detail.open || strings.Contains(m.View(), "details of") {
+		t.Error("'v' should close the detail pane")
+	}
+}
+
+func TestModel_Detail_NarrowReplacesList(t *testing.T) {
+	fetch := func(Item) (func(int) string, error) {
+		return func(int) string { return "the details" }, nil
+	}
+	m := detailModel(t, 80, fetch)
+
+	m, cmd := pressKey(t, m, "v")
+	m, _ = update(t, m, cmd())
+
+	view := m.View()
+	if !strings.Contains(view, "the details") {
+		t.Error("View() should show the fetched details")
+	}
+	if strings.Contains(view, "Fix bug") {
+		t.Error("the detail pane should replace the list in a narrow window")
+	}
+}
+
+func TestModel_Detail_FetchError(t *testing.T) {
+	fetch := func(Item) (func(int) string, error) {
+		return nil, errors.New("boom")
+	}
+	m := detailModel(t, 140, fetch)
+
+	m, cmd := pressKey(t, m, "v")
+	m, _ = update(t, m, cmd())
+
+	if view := m.View(); !strings.Contains(view, "Failed to load details: boom") {
+		t.Error("View() should show why the details failed to load")
+	}
+}
+
+func TestModel_DetailError_NotFetchedHint(t *testing.T) {
+	refresh := FetchCmd(func() ([]Tab, error) { return nil, nil })
+	tests := []struct {
+		name string
+		m    func(Model) Model
+		hint string
+	}{
+		{"no refresh", func(m Model) Model { return m }, ""},
+		{"refreshable", func(m Model) Model { m.fetchCmd = refresh; return m }, "; refresh with r"},
+		{"refreshing", func(m Model) Model { m.fetchCmd = refresh; m.refreshing = true; return m }, "; wait for the refresh to finish"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.m(actionModel(t))
+
+			want := "Failed to load details: " + ErrNotFetched.Error() + tt.hint
+			if got := m.detailError(ErrNotFetched); got != want {
+				t.Errorf("detailError() = %q, want %q", got, want)
+			}
+			if got := m.detailError(errors.New("boom")); got != "Failed to load details: boom" {
+				t.Errorf("detailError() = %q, want no hint for other errors", got)
+			}
+		})
+	}
+}
+
+func TestModel_Detail_NotOfferedWithoutFetch(t *testing.T) {
+	m := actionModel(t)
+
+	m, _ = pressKey(t, m, "v")
+	if m.detail.open {
+		t.Error("'v' should do nothing without a detail fetcher")
+	}
+	if strings.Contains(m.View(), "details") {
+		t.Error("help should not offer details without a detail fetcher")
+	}
+}
@@ -85,6 +85,25 @@ func HumanizeTabName(s string) string {
 	return strings.Join(words, " ")
 }
 
+// TabTitle formats a tab name with its item count, e.g. "Created (3)", or
+// "Created (50 of 312)" when only some of the matching items were fetched.
+func TabTitle(name string, shown, total int) string {
+	if total > shown {
+		return fmt.Sprintf("%s (%d of %d)", name, shown, total)
+	}
+	return fmt.Sprintf("%s (%d)", name, shown)
+}
+
+// ErrorTabTitle formats the name of a tab whose search failed, e.g.
+// "Needs Triage (error: invalid query)", or "Participated (3, error: HTTP 502)"
+// when some items were still fetched.
+func ErrorTabTitle(name string, shown int, summary string) string {
+	if shown > 0 {
+		return fmt.Sprintf("%s (%d, error: %s)", name, shown, summary)
+	}
+	return fmt.Sprintf("%s (error: %s)", name, summary)
+}
+
 func humanizeDuration(d time.Duration) string {
 	if d < time.Minute {
 		return "just now"
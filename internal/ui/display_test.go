@@ -209,3 +209,33 @@ func TestHumanizeTabName(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorTabTitle(t *testing.T) {
+	if got, want := ErrorTabTitle("Needs Triage", 0, "invalid query"), "Needs Triage (error: invalid query)"; got != want {
+		t.Errorf("ErrorTabTitle() = %q, want %q", got, want)
+	}
+	if got, want := ErrorTabTitle("Participated", 3, "HTTP 502"), "Participated (3, error: HTTP 502)"; got != want {
+		t.Errorf("ErrorTabTitle() = %q, want %q", got, want)
+	}
+}
+
+func TestTabTitle(t *testing.T) {
+	tests := []struct {
+		name  string
+		shown int
+		total int
+		want  string
+	}{
+		{"all fetched", 3, 3, "Created (3)"},
+		{"unknown total", 3, 0, "Created (3)"},
+		{"partially fetched", 50, 312, "Created (50 of 312)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TabTitle("Created", tt.shown, tt.total); got != tt.want {
+				t.Errorf("TabTitle() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"maps"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// section heads the items of one repository in a tab grouped by repository.
+type section struct {
+	repo  string
+	count int
+	// hidden holds the items of a collapsed section, which are left out of
+	// the list.
+	hidden    []list.Item
+	collapsed bool
+}
+
+// FilterValue implements list.Item.
+func (s section) FilterValue() string {
+	return s.repo
+}
+
+// toggleGroups shows the items of every tab in sections per repository, or
+// lists them flat again.
+func (m Model) toggleGroups() (Model, tea.Cmd, bool) {
+	if m.tabs[m.activeTab].list.FilterState() == list.Filtering {
+		return m, nil, false
+	}
+	m.grouped = !m.grouped
+	cmd := m.groupTabs()
+	m.statusMsg = "Listed flat"
+	if m.grouped {
+		m.statusMsg = "Grouped by repository"
+	}
+	m, detailCmd := m.syncDetail()
+	return m, tea.Batch(cmd, detailCmd, tea.Tick(2*time.Second, func(time.Time) tea.Msg { return clearStatusMsg{} })), true
+}
+
+// groupTabs groups or ungroups the tabs that do not match the model yet, such
+// as freshly loaded ones.
+func (m Model) groupTabs() tea.Cmd {
+	var cmds []tea.Cmd
+	for i := range m.tabs {
+		if t := &m.tabs[i]; t.grouped != m.grouped {
+			t.grouped = m.grouped
+			cmds = append(cmds, t.arrange())
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// toggleSection collapses the selected section, or expands it again. It is
+// not handled unless a section is selected.
+func (m Model) toggleSection() (Model, tea.Cmd, bool) {
+	t := &m.tabs[m.activeTab]
+	s, ok := t.list.SelectedItem().(section)
+	if !ok {
+		return m, nil, false
+	}
+	t.collapsed = maps.Clone(t.collapsed)
+	if t.collapsed == nil {
+		t.collapsed = make(map[string]bool)
+	}
+	t.collapsed[s.repo] = !s.collapsed
+	return m, t.arrange(), true
+}
+
+// flatItems returns the items of the tab without section headers, including
+// those hidden in collapsed sections.
+func (t *Tab) flatItems() []list.Item {
+	items := make([]list.Item, 0, len(t.list.Items()))
+	for _, li := range t.list.Items() {
+		if s, ok := li.(section); ok {
+			items = append(items, s.hidden...)
+			continue
+		}
+		items = append(items, li)
+	}
+	return items
+}
+
+// setItems shows items, in sections per repository if the tab is grouped,
+// keeping the selected item or section selected.
+func (t *Tab) setItems(items []list.Item) tea.Cmd {
+	selected := selectionKey(t.list.SelectedItem())
+	if t.grouped {
+		items = groupByRepo(items, t.collapsed)
+	}
+	t.list.SetDelegate(newGithubDelegate().withGrouped(t.grouped))
+	cmd := t.list.SetItems(items)
+	t.selectKey(selected)
+	return cmd
+}
+
+// selectKey selects the item or section identified by key, unless the list is
+// filtered.
+func (t *Tab) selectKey(key string) {
+	if key == "" || t.list.FilterState() != list.Unfiltered {
+		return
+	}
+	for j, li := range t.list.Items() {
+		if selectionKey(li) == key {
+			t.list.Select(j)
+			return
+		}
+	}
+}
+
+// selectionKey identifies an item or section across calls to setItems.
+func selectionKey(li list.Item) string {
+	switch v := li.(type) {
+	case Item:
+		return v.url
+	case section:
+		return "section:" + v.repo
+	}
+	return ""
+}
+
+// groupByRepo heads items with a section per repository. Sections are in the
+// order their repository first appears, so they follow the sort of the tab,
+// and the items of collapsed repositories are hidden in their section.
+func groupByRepo(items []list.Item, collapsed map[string]bool) []list.Item {
+	var repos []string
+	byRepo := make(map[string][]list.Item)
+	for _, li := range items {
+		var repo string
+		if it, ok := li.(Item); ok {
+			repo = it.repoName
+		}
+		if _, seen := byRepo[repo]; !seen {
+			repos = append(repos, repo)
+		}
+		byRepo[repo] = append(byRepo[repo], li)
+	}
+
+	grouped := make([]list.Item, 0, len(items)+len(repos))
+	for _, repo := range repos {
+		s := section{repo: repo, count: len(byRepo[repo]), collapsed: collapsed[repo]}
+		if s.collapsed {
+			s.hidden = byRepo[repo]
+			grouped = append(grouped, s)
+			continue
+		}
+		grouped = append(grouped, s)
+		grouped = append(grouped, byRepo[repo]...)
+	}
+	return grouped
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// listEntries describes the list of the active tab, naming sections by their
+// header and items by their data.
+func listEntries(m Model) []any {
+	var entries []any
+	for _, li := range m.tabs[m.activeTab].list.Items() {
+		switch v := li.(type) {
+		case section:
+			entries = append(entries, v.repo)
+		case Item:
+			entries = append(entries, v.Data())
+		}
+	}
+	return entries
+}
+
+func TestModel_Group_TogglesSections(t *testing.T) {
+	m := marksModel(t)
+
+	m, _ = pressKey(t, m, "z")
+	want := []any{"owner/repo", 1, 2, "owner/other", 3}
+	if got := listEntries(m); !slices.Equal(got, want) {
+		t.Fatalf("entries = %v, want %v", got, want)
+	}
+	if it, ok := m.selectedItem(); !ok || it.Data() != 1 {
+		t.Errorf("selected %v, want the selection to stay on the first item", m.tabs[0].list.SelectedItem())
+	}
+	view := m.View()
+	if !strings.Contains(view, "▾ owner/repo (2)") || !strings.Contains(view, "▾ owner/other (1)") {
+		t.Error("View() should show a header with the count of each repository")
+	}
+
+	m, _ = pressKey(t, m, "z")
+	if got := listEntries(m); !slices.Equal(got, []any{1, 2, 3}) {
+		t.Errorf("entries = %v, want 'z' to list the items flat again", got)
+	}
+}
+
+func TestModel_Group_EnterCollapsesSection(t *testing.T) {
+	m := marksModel(t)
+	m, _ = pressKey(t, m, " ")
+	m, _ = pressKey(t, m, "z")
+	m.tabs[0].list.Select(0)
+
+	m, _ = pressKey(t, m, "enter")
+	if got := listEntries(m); !slices.Equal(got, []any{"owner/repo", "owner/other", 3}) {
+		t.Fatalf("entries = %v, want the items of owner/repo hidden", got)
+	}
+	if !strings.Contains(m.View(), "▸ owner/repo (2)") {
+		t.Error("View() should show the section as collapsed with its count")
+	}
+	if got := markedData(m); len(got) != 1 || got[0] != 1 {
+		t.Errorf("marked %v, want items in collapsed sections to stay marked", got)
+	}
+
+	m, _ = pressKey(t, m, "enter")
+	if got := listEntries(m); !slices.Equal(got, []any{"owner/repo", 1, 2, "owner/other", 3}) {
+		t.Errorf("entries = %v, want enter to expand the section again", got)
+	}
+}
+
+func TestModel_Group_FollowsSort(t *testing.T) {
+	m := marksModel(t)
+	m.tabs[0] = m.tabs[0].WithSorts(sortTestSorts, "")
+	m, _ = pressKey(t, m, "z")
+	m, _ = pressKey(t, m, "s")
+	m, _ = pressKey(t, m, "s")
+
+	want := []any{"owner/other", 3, "owner/repo", 2, 1}
+	if got := listEntries(m); !slices.Equal(got, want) {
+		t.Errorf("entries = %v, want sections in the order of their first item, %v", got, want)
+	}
+}
+
+func TestModel_Group_RemoveUpdatesCount(t *testing.T) {
+	m := marksModel(t)
+	m, _ = pressKey(t, m, "z")
+
+	m, _ = m.removeItem("https://example.com/3")
+	if got := listEntries(m); !slices.Equal(got, []any{"owner/repo", 1, 2}) {
+		t.Errorf("entries = %v, want the emptied section dropped", got)
+	}
+	m, _ = m.removeItem("https://example.com/1")
+	if !strings.Contains(m.View(), "▾ owner/repo (1)") {
+		t.Error("View() should count the items left in the section")
+	}
+}
+
+func TestCarryOver_KeepsSections(t *testing.T) {
+	m := marksModel(t)
+	m, _ = pressKey(t, m, "z")
+	m.tabs[0].list.Select(3)
+	m, _ = pressKey(t, m, "enter")
+
+	tabs, _ := carryOver(m.tabs, marksModel(t).tabs, false)
+	m.tabs = tabs
+	if got := listEntries(m); !slices.Equal(got, []any{"owner/repo", 1, 2, "owner/other"}) {
+		t.Errorf("entries = %v, want the refreshed tab grouped with owner/other collapsed", got)
+	}
+	if _, ok := m.tabs[0].list.SelectedItem().(section); !ok {
+		t.Error("the collapsed section should stay selected")
+	}
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// toggleMark marks the selected item for a bulk action, or unmarks it.
+func (m Model) toggleMark() (Model, tea.Cmd, bool) {
+	if m.tabs[m.activeTab].list.FilterState() == list.Filtering {
+		return m, nil, false
+	}
+	it, ok := m.selectedItem()
+	if !ok {
+		return m, nil, true
+	}
+	cmd := m.setMarked(map[string]bool{it.url: true}, !it.marked)
+	return m, cmd, true
+}
+
+// markAll marks every item shown in the active tab, which respects the
+// filter, or unmarks them all if they already are.
+func (m Model) markAll() (Model, tea.Cmd, bool) {
+	if m.tabs[m.activeTab].list.FilterState() == list.Filtering {
+		return m, nil, false
+	}
+	urls := make(map[string]bool)
+	all := true
+	for _, li := range m.tabs[m.activeTab].list.VisibleItems() {
+		if it, ok := li.(Item); ok {
+			urls[it.url] = true
+			all = all && it.marked
+		}
+	}
+	cmd := m.setMarked(urls, !all)
+	return m, cmd, true
+}
+
+// setMarked marks or unmarks the items of the active tab with the given URLs,
+// including those hidden in collapsed sections.
+func (m Model) setMarked(urls map[string]bool, marked bool) tea.Cmd {
+	t := &m.tabs[m.activeTab]
+	items := t.flatItems()
+	changed := false
+	for i, li := range items {
+		it, ok := li.(Item)
+		if !ok || !urls[it.url] || it.marked == marked {
+			continue
+		}
+		it.marked = marked
+		items[i] = it
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return t.setItems(items)
+}
+
+// markedItems returns the marked items of the active tab, including those in
+// collapsed sections.
+func (m Model) markedItems() []Item {
+	var marked []Item
+	for _, li := range m.tabs[m.activeTab].flatItems() {
+		if it, ok := li.(Item); ok && it.marked {
+			marked = append(marked, it)
+		}
+	}
+	return marked
+}
+
+// openMarked opens every marked item in the browser.
+func (m Model) openMarked(marked []Item) (Model, tea.Cmd, bool) {
+	cmds := make([]tea.Cmd, 0, len(marked)+1)
+	for _, it := range marked {
+		if it.url != "" {
+			cmds = append(cmds, openURLCmd(it.url))
+		}
+	}
+	m.statusMsg = fmt.Sprintf("→ Opening %d items in browser…", len(cmds))
+	cmds = append(cmds, tea.Tick(2*time.Second, func(time.Time) tea.Msg { return clearStatusMsg{} }))
+	return m, tea.Batch(cmds...), true
+}
@@ -0,0 +1,209 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// marksModel returns a sized model with three items in a tab keyed "created".
+func marksModel(t *testing.T, actions ...Action) Model {
+	t.Helper()
+	items := []list.Item{
+		NewItem("owner/repo", "#1 First", "desc", "https://example.com/1").WithData(1),
+		NewItem("owner/repo", "#2 Second", "desc", "https://example.com/2").WithData(2),
+		NewItem("owner/other", "#3 Third", "desc", "https://example.com/3").WithData(3),
+	}
+	m := NewModel([]Tab{NewTab("Created (3)", CreateList(items)).WithKey("created")}).WithActions(actions...)
+	newModel, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+	return m
+}
+
+func markedData(m Model) []any {
+	var data []any
+	for _, it := range m.markedItems() {
+		data = append(data, it.Data())
+	}
+	return data
+}
+
+func TestModel_Marks_SpaceToggles(t *testing.T) {
+	m := marksModel(t)
+
+	m, _ = pressKey(t, m, " ")
+	m, _ = pressKey(t, m, "down")
+	m, _ = pressKey(t, m, " ")
+	if got := markedData(m); len(got) != 2 {
+		t.Fatalf("marked %v, want the first two items", got)
+	}
+	if view := m.View(); !strings.Contains(view, "2 marked") || !strings.Contains(view, "◉") {
+		t.Error("View() should show the marks and how many items are marked")
+	}
+
+	m, _ = pressKey(t, m, " ")
+	if got := markedData(m); len(got) != 1 || got[0] != 1 {
+		t.Errorf("marked %v, want space to unmark the second item", got)
+	}
+}
+
+func TestModel_Marks_StarMarksAllOrNone(t *testing.T) {
+	m := marksModel(t)
+
+	m, _ = pressKey(t, m, " ")
+	m, _ = pressKey(t, m, "*")
+	if got := markedData(m); len(got) != 3 {
+		t.Errorf("marked %v, want '*' to mark every item", got)
+	}
+	m, _ = pressKey(t, m, "*")
+	if got := markedData(m); len(got) != 0 {
+		t.Errorf("marked %v, want '*' to unmark every item once all are marked", got)
+	}
+}
+
+func TestModel_Marks_OnlyBulkActionsOffered(t *testing.T) {
+	run := func(Item, ActionInput) (ActionResult, error) { return ActionResult{}, nil }
+	m := marksModel(t,
+		Action{Key: "R", Help: "review", Run: run},
+		Action{Key: "L", Help: "label", Bulk: true, Run: run},
+	)
+
+	m, _ = pressKey(t, m, " ")
+	view := m.View()
+	if strings.Contains(view, "R review") {
+		t.Error("help line should not list actions without bulk support while items are marked")
+	}
+	if !strings.Contains(view, "L label") {
+		t.Error("help line should list bulk actions while items are marked")
+	}
+}
+
+func TestModel_Marks_OptionsOnlyForOneRepository(t *testing.T) {
+	m := marksModel(t, Action{
+		Key:     "E",
+		Help:    "reviewers",
+		Bulk:    true,
+		Options: func(Item) (Options, error) { return Options{All: []string{"alice"}}, nil },
+		Run:     func(Item, ActionInput) (ActionResult, error) { return ActionResult{}, nil },
+	})
+
+	m, _ = pressKey(t, m, " ")
+	m, _ = pressKey(t, m, "down")
+	m, _ = pressKey(t, m, " ")
+	if !strings.Contains(m.View(), "E reviewers") {
+		t.Error("help line should list the action for marked items of one repository")
+	}
+
+	m, _ = pressKey(t, m, "down")
+	m, _ = pressKey(t, m, " ")
+	if strings.Contains(m.View(), "E reviewers") {
+		t.Error("help line should not list the action for marked items of several repositories")
+	}
+	if m, cmd := pressKey(t, m, "E"); m.pending != nil || cmd != nil {
+		t.Error("'E' should not start the action for marked items of several repositories")
+	}
+}
+
+func TestModel_Marks_BulkActionRunsOnEachMarkedItem(t *testing.T) {
+	var ran []any
+	m := marksModel(t, Action{
+		Key:    "L",
+		Help:   "label",
+		Bulk:   true,
+		Prompt: func(Item, string) Prompt { return Prompt{Label: "Labels", Required: true} },
+		Run: func(it Item, in ActionInput) (ActionResult, error) {
+			ran = append(ran, it.Data())
+			if in.Text != "bug" {
+				t.Errorf("Text = %q, want bug", in.Text)
+			}
+			if it.Data() == 3 {
+				return ActionResult{}, errors.New("no label \"bug\"")
+			}
+			return ActionResult{}, nil
+		},
+	})
+	m, _ = pressKey(t, m, " ")
+	m, _ = pressKey(t, m, "down")
+	m, _ = pressKey(t, m, "down")
+	m, _ = pressKey(t, m, " ")
+
+	m, _ = pressKey(t, m, "L")
+	m, _ = pressKey(t, m, "bug")
+	m, cmd := pressKey(t, m, "enter")
+	if m.statusMsg != "→ label 2 items…" {
+		t.Errorf("statusMsg = %q, want it to name how many items the action runs on", m.statusMsg)
+	}
+	m, _ = update(t, m, cmd())
+
+	if len(ran) != 2 || ran[0] != 1 || ran[1] != 3 {
+		t.Errorf("ran on %v, want [1 3]", ran)
+	}
+	if want := `label: 1 of 2 done, failed: no label "bug"`; m.statusMsg != want {
+		t.Errorf("statusMsg = %q, want %q", m.statusMsg, want)
+	}
+	if got := markedData(m); len(got) != 1 || got[0] != 3 {
+		t.Errorf("marked %v, want only the failed item to stay marked", got)
+	}
+}
+
+func TestModel_Marks_BulkActionUnmarksCollapsedItems(t *testing.T) {
+	m := marksModel(t, Action{
+		Key:  "x",
+		Help: "close",
+		Bulk: true,
+		Run:  func(Item, ActionInput) (ActionResult, error) { return ActionResult{}, nil },
+	})
+	m, _ = pressKey(t, m, "*")
+	m, _ = pressKey(t, m, "z")
+	m.tabs[0].list.Select(0)
+	m, _ = pressKey(t, m, "enter")
+	if s, ok := m.tabs[0].list.Items()[0].(section); !ok || !s.collapsed {
+		t.Fatal("owner/repo should be collapsed")
+	}
+
+	m, cmd := pressKey(t, m, "x")
+	m, _ = update(t, m, cmd())
+
+	if got := markedData(m); len(got) != 0 {
+		t.Errorf("marked %v, want the items in the collapsed section unmarked too", got)
+	}
+}
+
+func TestModel_Marks_EnterOpensAllMarked(t *testing.T) {
+	m := marksModel(t)
+
+	m, _ = pressKey(t, m, "*")
+	m, cmd := pressKey(t, m, "enter")
+	if cmd == nil || m.statusMsg != "→ Opening 3 items in browser…" {
+		t.Errorf("statusMsg = %q, want enter to open every marked item", m.statusMsg)
+	}
+}
+
+func TestModel_Marks_SurviveRefresh(t *testing.T) {
+	m := marksModel(t)
+	m, _ = pressKey(t, m, " ")
+
+	refreshed := []list.Item{
+		NewItem("owner/repo", "#1 First", "desc", "https://example.com/1").WithData(1),
+		NewItem("owner/repo", "#2 Second", "desc", "https://example.com/2").WithData(2),
+	}
+	m, _ = update(t, m, TabsMsg{NewTab("Created (2)", CreateList(refreshed)).WithKey("created")})
+
+	if got := markedData(m); len(got) != 1 || got[0] != 1 {
+		t.Errorf("marked %v after refresh, want [1]", got)
+	}
+}
+
+func TestActionInput_List(t *testing.T) {
+	got := ActionInput{Text: "bug, help wanted ,, docs"}.List()
+	want := []string{"bug", "help wanted", "docs"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("List() = %q, want %q", got, want)
+	}
+}
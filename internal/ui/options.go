@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"errors"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// errNoOptions is reported when a picker has nothing to offer.
+var errNoOptions = errors.New("nothing to pick from")
+
+// Options are what a picker of an action offers, any number of which are
+// picked at once.
+type Options struct {
+	All []string
+	// Selected are picked to begin with, e.g. the labels an issue already has.
+	Selected []string
+}
+
+// optionsMsg delivers the fetched options of a pending action.
+type optionsMsg struct {
+	pending *pendingAction
+	options Options
+	err     error
+}
+
+// optionSet is the state of a picker of options.
+type optionSet struct {
+	all      []string
+	selected map[string]bool
+	picked   map[string]bool
+}
+
+func newOptionSet(o Options) optionSet {
+	s := optionSet{selected: make(map[string]bool), picked: make(map[string]bool)}
+	for _, v := range o.Selected {
+		s.selected[v] = true
+		s.picked[v] = true
+	}
+	s.all = append(s.all, o.All...)
+	for _, v := range o.Selected {
+		if !slices.Contains(o.All, v) {
+			s.all = append(s.all, v)
+		}
+	}
+	return s
+}
+
+// added returns the options picked that were not selected to begin with.
+func (s optionSet) added() []string {
+	var added []string
+	for _, v := range s.all {
+		if s.picked[v] && !s.selected[v] {
+			added = append(added, v)
+		}
+	}
+	return added
+}
+
+// removed returns the options selected to begin with that were unpicked.
+func (s optionSet) removed() []string {
+	var removed []string
+	for _, v := range s.all {
+		if s.selected[v] && !s.picked[v] {
+			removed = append(removed, v)
+		}
+	}
+	return removed
+}
+
+// lines renders the options with whether each is picked.
+func (s optionSet) lines() []string {
+	lines := make([]string, len(s.all))
+	for i, v := range s.all {
+		if s.picked[v] {
+			lines[i] = "[x] " + v
+		} else {
+			lines[i] = "[ ] " + v
+		}
+	}
+	return lines
+}
+
+// fetchOptions fetches the options of the pending action p.
+func fetchOptions(p *pendingAction) tea.Cmd {
+	return func() tea.Msg {
+		options, err := p.action.Options(p.item)
+		return optionsMsg{pending: p, options: options, err: err}
+	}
+}
+
+// handleOptions shows the fetched options of the pending action, unless it
+// was cancelled meanwhile.
+func (m Model) handleOptions(msg optionsMsg) (Model, tea.Cmd) {
+	p := m.pending
+	if p != msg.pending {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.pending = nil
+		return m.actionFailed(p.action, msg.err)
+	}
+	p.loading = false
+	p.options = newOptionSet(msg.options)
+	if len(p.options.all) == 0 {
+		m.pending = nil
+		return m.actionFailed(p.action, errNoOptions)
+	}
+	return m, nil
+}
+
+// handleOptionsKey moves through the options of the pending action, picks
+// and unpicks them, and runs the action with what changed.
+func (m Model) handleOptionsKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	p := m.pending
+	switch msg.String() {
+	case "up", "k", "shift+tab":
+		p.cursor = (p.cursor - 1 + len(p.options.all)) % len(p.options.all)
+	case "down", "j", "tab":
+		p.cursor = (p.cursor + 1) % len(p.options.all)
+	case " ":
+		v := p.options.all[p.cursor]
+		p.options.picked[v] = !p.options.picked[v]
+	case "enter":
+		if len(p.options.added()) == 0 && len(p.options.removed()) == 0 {
+			m.pending = nil
+			return m, nil
+		}
+		mm, cmd, _ := m.afterChoice("")
+		return mm, cmd
+	}
+	return m, nil
+}
@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestModel_Action_PickOptions(t *testing.T) {
+	var gotInput ActionInput
+	m := actionModel(t, Action{
+		Key:  "L",
+		Help: "label",
+		Options: func(Item) (Options, error) {
+			return Options{All: []string{"bug", "docs", "triage"}, Selected: []string{"triage"}}, nil
+		},
+		Run: func(_ Item, in ActionInput) (ActionResult, error) {
+			gotInput = in
+			return ActionResult{}, nil
+		},
+	})
+
+	m, cmd := pressKey(t, m, "L")
+	if m.pending == nil || !m.pending.loading || cmd == nil {
+		t.Fatal("'L' should fetch the options")
+	}
+	if !strings.Contains(m.View(), "Loading") {
+		t.Error("View() should show that the options are loading")
+	}
+
+	m, _ = update(t, m, cmd())
+	view := m.View()
+	if !strings.Contains(view, "[ ] bug") || !strings.Contains(view, "[x] triage") {
+		t.Errorf("View() should list the options with the selected ones picked, got:\n%s", view)
+	}
+
+	m, _ = pressKey(t, m, " ")
+	m, _ = pressKey(t, m, "down")
+	m, _ = pressKey(t, m, "down")
+	m, _ = pressKey(t, m, " ")
+	m, cmd = pressKey(t, m, "enter")
+	if m.pending != nil || cmd == nil {
+		t.Fatal("enter should run the action")
+	}
+	cmd()
+	if !reflect.DeepEqual(gotInput.Added, []string{"bug"}) || !reflect.DeepEqual(gotInput.Removed, []string{"triage"}) {
+		t.Errorf("input = %+v, want bug added and triage removed", gotInput)
+	}
+}
+
+func TestModel_Action_PickOptionsUnchanged(t *testing.T) {
+	m := actionModel(t, Action{
+		Key:     "L",
+		Help:    "label",
+		Options: func(Item) (Options, error) { return Options{All: []string{"bug"}}, nil },
+		Run: func(Item, ActionInput) (ActionResult, error) {
+			t.Error("Run should not be called when nothing changed")
+			return ActionResult{}, nil
+		},
+	})
+
+	m, cmd := pressKey(t, m, "L")
+	m, _ = update(t, m, cmd())
+	m, cmd = pressKey(t, m, "enter")
+	if m.pending != nil || cmd != nil {
+		t.Error("enter without changes should close the picker")
+	}
+}
+
+func TestModel_Action_OptionsFailed(t *testing.T) {
+	m := actionModel(t, Action{
+		Key:     "L",
+		Help:    "label",
+		Options: func(Item) (Options, error) { return Options{}, errors.New("boom") },
+		Run:     func(Item, ActionInput) (ActionResult, error) { return ActionResult{}, nil },
+	})
+
+	m, cmd := pressKey(t, m, "L")
+	m, _ = update(t, m, cmd())
+	if m.pending != nil {
+		t.Error("a failed fetch should end the pending action")
+	}
+	if m.statusMsg != "Failed to label: boom" {
+		t.Errorf("statusMsg = %q, want %q", m.statusMsg, "Failed to label: boom")
+	}
+}
+
+func TestModel_Action_OptionsAfterCancel(t *testing.T) {
+	m := actionModel(t, Action{
+		Key:     "L",
+		Help:    "label",
+		Options: func(Item) (Options, error) { return Options{All: []string{"bug"}}, nil },
+		Run:     func(Item, ActionInput) (ActionResult, error) { return ActionResult{}, nil },
+	})
+
+	m, cmd := pressKey(t, m, "L")
+	m, _ = pressKey(t, m, "esc")
+	m, _ = update(t, m, cmd())
+	if m.pending != nil {
+		t.Error("options fetched after esc should be ignored")
+	}
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Sort is an order the items of a tab can be sorted in.
+type Sort struct {
+	Name string
+	// Compare orders two items, like the comparison functions of package slices.
+	Compare func(a, b Item) int
+}
+
+// WithSorts returns a copy of the tab whose items can be cycled through sorts
+// with "s", sorted by the sort named current; empty keeps the order they were
+// added in, e.g. as the search returned them, which "s" cycles to after the
+// last sort.
+func (t Tab) WithSorts(sorts []Sort, current string) Tab {
+	t.sorts = sorts
+	t.sortName = current
+	// A new tab is not filtered, so sorting its items needs no command.
+	_ = t.arrange()
+	return t
+}
+
+// SortName returns the name of the sort the items are in, or empty if they
+// are in the order they were added in.
+func (t Tab) SortName() string {
+	return t.sortName
+}
+
+// cycleSort sorts the items of the active tab by the sort after the current
+// one, or restores the order they were added in after the last sort.
+func (m Model) cycleSort() (Model, tea.Cmd, bool) {
+	t := &m.tabs[m.activeTab]
+	if len(t.sorts) == 0 || t.list.FilterState() == list.Filtering {
+		return m, nil, false
+	}
+	// After the last sort comes the order the items were added in.
+	next := ""
+	i := slices.IndexFunc(t.sorts, func(s Sort) bool { return s.Name == t.sortName })
+	if i+1 < len(t.sorts) {
+		next = t.sorts[i+1].Name
+	}
+	t.sortName = next
+	cmd := t.arrange()
+	m.statusMsg = "Sorted by " + cmp.Or(t.sortName, "search order")
+	m, detailCmd := m.syncDetail()
+	return m, tea.Batch(cmd, detailCmd, tea.Tick(3*time.Second, func(time.Time) tea.Msg { return clearStatusMsg{} })), true
+}
+
+// arrange sorts the items of the tab by its current sort, keeping the order
+// they were created in among equal items, or restores that order, and groups
+// them if the tab is grouped.
+func (t *Tab) arrange() tea.Cmd {
+	compare := func(a, b Item) int { return cmp.Compare(a.order, b.order) }
+	if i := slices.IndexFunc(t.sorts, func(s Sort) bool { return s.Name == t.sortName }); i >= 0 {
+		bySort, byOrder := t.sorts[i].Compare, compare
+		compare = func(a, b Item) int { return cmp.Or(bySort(a, b), byOrder(a, b)) }
+	}
+
+	items := t.flatItems()
+	slices.SortStableFunc(items, func(a, b list.Item) int {
+		ia, okA := a.(Item)
+		ib, okB := b.(Item)
+		if !okA || !okB {
+			return 0
+		}
+		return compare(ia, ib)
+	})
+	return t.setItems(items)
+}
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sortTestSorts order items by their int data, ascending and descending.
+var sortTestSorts = []Sort{
+	{Name: "asc", Compare: func(a, b Item) int { return compareData(a, b) }},
+	{Name: "desc", Compare: func(a, b Item) int { return compareData(b, a) }},
+}
+
+func compareData(a, b Item) int {
+	x, okA := a.Data().(int)
+	y, okB := b.Data().(int)
+	if !okA || !okB {
+		return 0
+	}
+	return cmp.Compare(x, y)
+}
+
+// sortModel returns a sized model with one tab of items carrying data, in order.
+func sortModel(t *testing.T, data ...int) Model {
+	t.Helper()
+	items := make([]list.Item, len(data))
+	for i, d := range data {
+		url := "https://example.com/" + string(rune('a'+d))
+		items[i] = NewItem("owner/repo", "item", "desc", url).WithData(d)
+	}
+	tab := NewTab("Created", CreateList(items)).WithSorts(sortTestSorts, "")
+	newModel, _ := NewModel([]Tab{tab}).Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+	return m
+}
+
+func listData(m Model) []any {
+	var data []any
+	for _, li := range m.tabs[m.activeTab].list.Items() {
+		if it, ok := li.(Item); ok {
+			data = append(data, it.Data())
+		}
+	}
+	return data
+}
+
+func TestModel_Sort_CyclesAndKeepsSelection(t *testing.T) {
+	m := sortModel(t, 2, 3, 1)
+	m, _ = pressKey(t, m, "down")
+
+	m, _ = pressKey(t, m, "s")
+	if got := listData(m); got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Fatalf("items = %v, want the first sort to order them ascending", got)
+	}
+	if it, ok := m.tabs[0].list.SelectedItem().(Item); !ok || it.Data() != 3 {
+		t.Errorf("selected %v, want the selection to follow the item", m.tabs[0].list.SelectedItem())
+	}
+	if m.statusMsg != "Sorted by asc" {
+		t.Errorf("statusMsg = %q, want %q", m.statusMsg, "Sorted by asc")
+	}
+
+	m, _ = pressKey(t, m, "s")
+	if got := listData(m); got[0] != 3 || got[2] != 1 {
+		t.Errorf("items = %v, want the second sort to order them descending", got)
+	}
+	m, _ = pressKey(t, m, "s")
+	if got := m.tabs[0].sortName; got != "" {
+		t.Errorf("sortName = %q, want the last sort to be followed by search order", got)
+	}
+	if got := listData(m); got[0] != 2 || got[1] != 3 || got[2] != 1 {
+		t.Errorf("items = %v, want them back in search order", got)
+	}
+	if m.statusMsg != "Sorted by search order" {
+		t.Errorf("statusMsg = %q, want %q", m.statusMsg, "Sorted by search order")
+	}
+	m, _ = pressKey(t, m, "s")
+	if got := m.tabs[0].sortName; got != "asc" {
+		t.Errorf("sortName = %q, want search order to be followed by the first sort", got)
+	}
+}
+
+func TestModel_Sort_ShownInHelpLine(t *testing.T) {
+	m := sortModel(t, 1, 2)
+	if strings.Contains(m.View(), "sort:") {
+		t.Error("help line should not show a sort while items are in search order")
+	}
+	m, _ = pressKey(t, m, "s")
+	m, _ = update(t, m, clearStatusMsg{})
+	if !strings.Contains(m.View(), "sort: asc") {
+		t.Error("help line should show the current sort")
+	}
+}
+
+func TestModel_Sort_IgnoredWithoutSorts(t *testing.T) {
+	m := marksModel(t)
+	m, _ = pressKey(t, m, "s")
+	if m.statusMsg != "" {
+		t.Errorf("statusMsg = %q, want 's' to do nothing on a tab without sorts", m.statusMsg)
+	}
+}
+
+func TestTab_WithSorts_SortsKeepingCreationOrder(t *testing.T) {
+	items := []list.Item{
+		NewItem("owner/repo", "first", "desc", "https://example.com/1").WithData(1),
+		NewItem("owner/repo", "second", "desc", "https://example.com/2").WithData(2),
+		NewItem("owner/repo", "third", "desc", "https://example.com/3").WithData(1),
+	}
+	tab := NewTab("Created", CreateList(items)).WithSorts(sortTestSorts, "desc")
+
+	var got []string
+	for _, li := range tab.list.Items() {
+		if it, ok := li.(Item); ok {
+			got = append(got, it.titleText)
+		}
+	}
+	if !slices.Equal(got, []string{"second", "first", "third"}) {
+		t.Fatalf("items = %v, want them sorted descending, equal ones in creation order", got)
+	}
+
+	tab.sortName = ""
+	tab.arrange()
+	if it, ok := tab.list.Items()[0].(Item); !ok || it.titleText != "first" {
+		t.Errorf("first item = %v, want the creation order restored", tab.list.Items()[0])
+	}
+}
+
+func TestCarryOver_KeepsSort(t *testing.T) {
+	m := sortModel(t, 1, 2, 3)
+	m, _ = pressKey(t, m, "s")
+	m, _ = pressKey(t, m, "s")
+
+	refreshed := sortModel(t, 4, 1, 2, 3)
+	tabs, _ := carryOver(m.tabs, refreshed.tabs, false)
+	var got []any
+	for _, li := range tabs[0].list.Items() {
+		if it, ok := li.(Item); ok {
+			got = append(got, it.Data())
+		}
+	}
+	if tabs[0].sortName != "desc" || got[0] != 4 || got[3] != 1 {
+		t.Errorf("sort %q, items %v, want the refreshed items sorted descending", tabs[0].sortName, got)
+	}
+}
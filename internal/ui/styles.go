@@ -14,6 +14,9 @@ var (
 	colorTitle     = lipgloss.AdaptiveColor{Light: "#000000", Dark: "#D1D5DB"} // slightly light
 	colorMuted     = lipgloss.AdaptiveColor{Light: "#6E7781", Dark: "#6E7681"} // GitHub muted
 	colorUser      = lipgloss.AdaptiveColor{Light: "#0969DA", Dark: "#2F81F7"} // GitHub blue for mentions
+	colorError     = lipgloss.AdaptiveColor{Light: "#CF222E", Dark: "#F85149"} // GitHub red
+	colorNew       = lipgloss.AdaptiveColor{Light: "#1A7F37", Dark: "#3FB950"} // GitHub green
+	colorUpdated   = lipgloss.AdaptiveColor{Light: "#9A6700", Dark: "#D29922"} // GitHub yellow
 )
 
 func GithubTabStyles() (active, inactive lipgloss.Style) {
@@ -41,32 +44,44 @@ var (
 	helpSepStyle  = lipgloss.NewStyle().Foreground(colorMuted)
 )
 
-func helpView(state list.FilterState) string {
-	var entries []struct{ key, desc string }
+// helpEntry is a key and what it does, shown in the help line.
+type helpEntry struct{ key, desc string }
+
+// helpView returns the help line for state. refresh lists the refresh key,
+// which is left out when the results cannot be refreshed, e.g. offline. extra
+// entries, such as the actions offered for the selected item, are listed
+// before quit.
+func helpView(state list.FilterState, refresh bool, extra ...helpEntry) string {
+	var entries []helpEntry
 
 	switch state {
 	case list.Filtering:
-		entries = []struct{ key, desc string }{
+		entries = []helpEntry{
 			{"esc", "exit filter"},
 			{"enter", "select"},
 		}
 	case list.FilterApplied:
-		entries = []struct{ key, desc string }{
+		entries = []helpEntry{
 			{"esc", "clear filter"},
 			{"tab", "switch tabs"},
 			{"enter", "open"},
-			{"ctrl+c", "quit"},
 		}
+		entries = append(entries, extra...)
+		entries = append(entries, helpEntry{"ctrl+c", "quit"})
 	default:
-		entries = []struct{ key, desc string }{
-			{"/", "filter"},
-			{"r", "refresh"},
-			{"tab", "switch tabs"},
-			{"enter", "open"},
-			{"ctrl+c", "quit"},
+		entries = []helpEntry{{"/", "filter"}}
+		if refresh {
+			entries = append(entries, helpEntry{"r", "refresh"})
 		}
+		entries = append(entries, helpEntry{"tab", "switch tabs"}, helpEntry{"enter", "open"})
+		entries = append(entries, extra...)
+		entries = append(entries, helpEntry{"ctrl+c", "quit"})
 	}
 
+	return renderHelp(entries)
+}
+
+func renderHelp(entries []helpEntry) string {
 	var parts []string
 	for _, e := range entries {
 		parts = append(parts, helpKeyStyle.Render(e.key)+" "+helpDescStyle.Render(e.desc))
@@ -80,4 +95,15 @@ var (
 	DocStyle    = lipgloss.NewStyle().Padding(1, 2, 1, 2)
 	WindowStyle = lipgloss.NewStyle().Align(lipgloss.Left)
 	StatusStyle = lipgloss.NewStyle().Foreground(colorAccent)
+	InfoStyle   = lipgloss.NewStyle().Foreground(colorMuted)
+	ErrorStyle  = lipgloss.NewStyle().Foreground(colorError)
+	// DetailStyle separates the detail pane from the list next to it.
+	DetailStyle = lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			BorderForeground(colorMuted).
+			Padding(0, 1)
+
+	MarkStyle         = lipgloss.NewStyle().Foreground(colorAccent).Bold(true)
+	NewBadgeStyle     = lipgloss.NewStyle().Foreground(colorNew)
+	UpdatedBadgeStyle = lipgloss.NewStyle().Foreground(colorUpdated)
 )
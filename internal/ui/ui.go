@@ -2,6 +2,7 @@
 package ui
 
 import (
+	"fmt"
 	"log/slog"
 	"os/exec"
 	"runtime"
@@ -12,10 +13,19 @@ import (
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 type Item struct {
 	repoName, titleText, titleSuffix, description, url string
+	change                                             change
+	data                                               any
+	// marked selects the item for a bulk action.
+	marked bool
+	// order is the position the item was created at, which restores the
+	// search order once the tab is no longer sorted or grouped, and orders
+	// the items a sort ranks equal.
+	order int
 }
 
 func NewItem(repoName, titleText, description, url string) Item {
@@ -34,6 +44,25 @@ func (i Item) WithSuffix(s string) Item {
 	return i
 }
 
+// WithTitleText returns a copy of the item with the given title text, e.g. to
+// show a change made by an action.
+func (i Item) WithTitleText(s string) Item {
+	i.titleText = s
+	return i
+}
+
+// WithData returns a copy of the item carrying data, such as the pull request
+// it was built from, for actions to use.
+func (i Item) WithData(data any) Item {
+	i.data = data
+	return i
+}
+
+// Data returns the data attached with WithData.
+func (i Item) Data() any {
+	return i.data
+}
+
 func (i Item) Title() string {
 	if i.repoName == "" {
 		return i.titleText
@@ -49,6 +78,12 @@ func (i Item) FilterValue() string {
 }
 
 func CreateList(items []list.Item) list.Model {
+	for i, li := range items {
+		if it, ok := li.(Item); ok {
+			it.order = i
+			items[i] = it
+		}
+	}
 	delegate := newGithubDelegate()
 	l := list.New(items, delegate, 0, 0)
 	l.SetShowTitle(false)
@@ -61,7 +96,23 @@ func CreateList(items []list.Item) list.Model {
 
 type Tab struct {
 	name string
+	key  string
 	list list.Model
+	err  error
+	// title derives name from the number of items shown and matched, so it
+	// stays accurate when items are removed.
+	title func(shown, total int) string
+	total int
+	// closed tabs may list closed items, so closing an item keeps it there.
+	closed bool
+	// sorts are the orders the items can be cycled through, and sortName
+	// names the current one.
+	sorts    []Sort
+	sortName string
+	// grouped shows the items in sections per repository, and collapsed
+	// holds the repositories whose sections are collapsed.
+	grouped   bool
+	collapsed map[string]bool
 }
 
 func NewTab(name string, list list.Model) Tab {
@@ -75,6 +126,46 @@ func (t Tab) Name() string {
 	return t.name
 }
 
+// WithKey returns a copy of the tab identified by key, such as "created",
+// which actions use to decide whether they apply.
+func (t Tab) WithKey(key string) Tab {
+	t.key = key
+	return t
+}
+
+// Key returns the key set with WithKey.
+func (t Tab) Key() string {
+	return t.key
+}
+
+// WithTitle returns a copy of the tab named by title, given the number of
+// items shown and the total number of matches.
+func (t Tab) WithTitle(total int, title func(shown, total int) string) Tab {
+	t.title = title
+	t.total = total
+	t.name = title(len(t.list.Items()), total)
+	return t
+}
+
+// WithError returns a copy of the tab marked as failed to load with err.
+func (t Tab) WithError(err error) Tab {
+	t.err = err
+	return t
+}
+
+// WithClosed returns a copy of the tab marked as listing closed items too,
+// e.g. because its query has no is:open qualifier, so items closed or merged
+// by an action stay in it.
+func (t Tab) WithClosed(closed bool) Tab {
+	t.closed = closed
+	return t
+}
+
+// Err returns the error the tab failed to load with, if any.
+func (t Tab) Err() error {
+	return t.err
+}
+
 // clearStatusMsg is sent after a delay to clear the status bar.
 type clearStatusMsg struct{}
 
@@ -90,21 +181,51 @@ type Model struct {
 	err       error
 	fetchCmd  tea.Cmd
 	statusMsg string
+	info      string
+	// staleSince is when the shown tabs were cached; it is zero once fresh
+	// tabs have been loaded.
+	staleSince time.Time
+	// loaded reports whether tabs holding results are shown, so that the
+	// next fetch can be compared against them.
+	loaded bool
+	// refreshInterval is how often the tabs are refetched in the background;
+	// zero disables auto-refresh.
+	refreshInterval time.Duration
+	refreshing      bool
+	actions         []Action
+	pending         *pendingAction
+	detail          detailPane
+	// editor composes messages of actions; empty composes them inline.
+	editor string
+	// grouped shows the items of every tab in sections per repository.
+	grouped bool
 }
 
 // TabsMsg signals that data loading is complete and tabs are ready.
 type TabsMsg []Tab
 
+// LoadedMsg signals that data loading is complete, carrying the tabs and
+// persistent status information such as the remaining API quota.
+type LoadedMsg struct {
+	Tabs []Tab
+	Info string
+}
+
 // ErrMsg signals that data loading failed.
 type ErrMsg struct{ Err error }
 
+// autoRefreshMsg is sent every refresh interval to refetch in the background.
+type autoRefreshMsg struct{}
+
 func NewModel(tabs []Tab) Model {
-	if len(tabs) == 0 {
+	loaded := len(tabs) > 0
+	if !loaded {
 		tabs = []Tab{NewTab("Empty", CreateList(nil))}
 	}
 	return Model{
 		tabs:      tabs,
 		activeTab: 0,
+		loaded:    loaded,
 	}
 }
 
@@ -120,6 +241,32 @@ func NewLoadingModel(fetch tea.Cmd) Model {
 	}
 }
 
+// WithInfo returns a copy of the model showing info at the right of the help line.
+func (m Model) WithInfo(info string) Model {
+	m.info = info
+	return m
+}
+
+// NewStaleModel returns a model showing tabs cached at cachedAt while fetch
+// refreshes them in the background.
+func NewStaleModel(tabs []Tab, cachedAt time.Time, fetch tea.Cmd) Model {
+	m := NewLoadingModel(fetch)
+	m.loading = false
+	m.staleSince = cachedAt
+	if len(tabs) > 0 {
+		m.tabs = tabs
+		m.loaded = true
+	}
+	return m
+}
+
+// WithAutoRefresh returns a copy of the model that refetches its tabs in the
+// background every interval, highlighting items that are new or changed.
+func (m Model) WithAutoRefresh(interval time.Duration) Model {
+	m.refreshInterval = interval
+	return m
+}
+
 // FetchCmd wraps a data-fetching function into a tea.Cmd.
 // On success it returns TabsMsg; on failure it returns ErrMsg.
 func FetchCmd(fn func() ([]Tab, error)) tea.Cmd {
@@ -132,11 +279,23 @@ func FetchCmd(fn func() ([]Tab, error)) tea.Cmd {
 	}
 }
 
+// FetchWithInfoCmd is like FetchCmd, but fn also returns status information
+// shown next to the help line. On success it returns LoadedMsg.
+func FetchWithInfoCmd(fn func() ([]Tab, string, error)) tea.Cmd {
+	return func() tea.Msg {
+		tabs, info, err := fn()
+		if err != nil {
+			return ErrMsg{Err: err}
+		}
+		return LoadedMsg{Tabs: tabs, Info: info}
+	}
+}
+
 func (m Model) Init() tea.Cmd {
-	if m.loading {
-		return tea.Batch(m.spinner.Tick, m.fetchCmd)
+	if m.loading || m.stale() {
+		return tea.Batch(m.spinner.Tick, m.fetchCmd, m.autoRefreshTick())
 	}
-	return nil
+	return m.autoRefreshTick()
 }
 
 // Err returns the error from a failed fetch, if any.
@@ -147,42 +306,23 @@ func (m Model) Err() error {
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
-		return m.handleWindowSize(msg), nil
+		return m.handleWindowSize(msg).syncDetail()
 	case tea.KeyMsg:
+		if m.pending != nil && msg.String() != "ctrl+c" {
+			return m.handlePendingKey(msg)
+		}
 		if mm, cmd, handled := m.handleKey(msg); handled {
 			return mm, cmd
 		}
-	case ErrMsg:
-		m.err = msg.Err
-		return m, tea.Quit
-	case TabsMsg:
-		m.loading = false
-		m.tabs = []Tab(msg)
-		if len(m.tabs) == 0 {
-			m.tabs = []Tab{NewTab("Empty", CreateList(nil))}
-		}
-		if m.activeTab >= len(m.tabs) {
-			m.activeTab = 0
-		}
-		if m.width > 0 {
-			m = m.handleWindowSize(tea.WindowSizeMsg{Width: m.width, Height: m.height})
-		}
-		return m, nil
-	case spinner.TickMsg:
-		if m.loading {
-			var cmd tea.Cmd
-			m.spinner, cmd = m.spinner.Update(msg)
-			return m, cmd
-		}
-		return m, nil
+	case actionDoneMsg, bulkDoneMsg, optionsMsg, composeMsg, editedMsg, detailMsg:
+		return m.handleActionMsg(msg)
+	case ErrMsg, TabsMsg, LoadedMsg, autoRefreshMsg, spinner.TickMsg:
+		return m.handleFetchMsg(msg)
 	case clearStatusMsg:
 		m.statusMsg = ""
 		return m, nil
 	}
-
-	var cmd tea.Cmd
-	m.tabs[m.activeTab].list, cmd = m.tabs[m.activeTab].list.Update(msg)
-	return m, cmd
+	return m.updateFocused(msg)
 }
 
 func (m Model) View() string {
@@ -194,19 +334,51 @@ func (m Model) View() string {
 
 	doc.WriteString(m.tabsView())
 
+	content := m.tabs[m.activeTab].list.View()
+	switch {
+	case m.pending != nil && !m.pending.typing:
+		content = m.pendingView()
+	case m.detail.open:
+		content = m.detailView(content)
+	}
 	doc.WriteString(
 		WindowStyle.
 			Width(m.outerW).
 			Height(m.outerH).
-			Render(m.tabs[m.activeTab].list.View()),
+			Render(content),
 	)
 
 	doc.WriteString("\n")
-	if m.statusMsg != "" {
-		doc.WriteString(StatusStyle.Render(m.statusMsg))
-	} else {
-		doc.WriteString(helpView(m.tabs[m.activeTab].list.FilterState()))
+	if m.hasTabErrors() {
+		if err := m.tabs[m.activeTab].err; err != nil {
+			doc.WriteString(ErrorStyle.Render("error: " + strings.SplitN(err.Error(), "\n", 2)[0]))
+		}
+		doc.WriteString("\n")
+	}
+	var status string
+	switch {
+	case m.pending != nil:
+		status = m.pendingStatus()
+	case m.statusMsg != "":
+		status = StatusStyle.Render(m.statusMsg)
+	default:
+		var extra []helpEntry
+		if m.detail.fetch != nil {
+			extra = append(extra, helpEntry{"v", "details"})
+		}
+		for _, a := range m.availableActions() {
+			extra = append(extra, helpEntry{a.Key, a.Help})
+		}
+		status = helpView(m.tabs[m.activeTab].list.FilterState(), m.fetchCmd != nil, extra...)
+		if name := m.tabs[m.activeTab].sortName; name != "" {
+			status = StatusStyle.Render("sort: "+name) + helpSepStyle.Render(" • ") + status
+		}
+		if n := len(m.markedItems()); n > 0 {
+			status = StatusStyle.Render(fmt.Sprintf("%d marked", n)) + helpSepStyle.Render(" • ") + status
+		}
+		status = ansi.Truncate(status, m.outerW, "…")
 	}
+	doc.WriteString(m.withInfo(status))
 
 	out := DocStyle.Render(doc.String())
 	return out
@@ -253,6 +425,164 @@ func (m Model) tabsView() string {
 	return lipgloss.JoinVertical(lipgloss.Left, row, line)
 }
 
+// stale reports whether the shown tabs come from the cache and are being refreshed.
+func (m Model) stale() bool {
+	return !m.staleSince.IsZero()
+}
+
+// withInfo right-aligns the persistent info after status, if there is room.
+func (m Model) withInfo(status string) string {
+	text := m.info
+	switch {
+	case m.stale():
+		// Spinner frames end with a space.
+		text = m.spinner.View() + "stale (" + UpdatedAgo(m.staleSince.Format(time.RFC3339)) + "), refreshing…"
+	case m.refreshing:
+		text = m.spinner.View() + "refreshing…"
+	}
+	if text == "" {
+		return status
+	}
+	info := InfoStyle.Render(text)
+	gap := m.outerW - lipgloss.Width(status) - lipgloss.Width(info)
+	if gap < 2 {
+		return status
+	}
+	return status + strings.Repeat(" ", gap) + info
+}
+
+// hasTabErrors reports whether any tab failed to load. The error line is
+// reserved whenever it does, so switching tabs does not shift the layout.
+func (m Model) hasTabErrors() bool {
+	for _, t := range m.tabs {
+		if t.err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (m Model) handleTabs(tabs []Tab) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	if m.loaded {
+		// Changes are only highlighted with --watch; otherwise the swap of
+		// cached for fresh results at startup would mark everything it touched.
+		tabs, cmd = carryOver(m.tabs, tabs, m.refreshInterval > 0)
+	}
+	m.loading = false
+	m.refreshing = false
+	m.staleSince = time.Time{}
+	m.tabs = tabs
+	m.loaded = len(tabs) > 0
+	if len(m.tabs) == 0 {
+		m.tabs = []Tab{NewTab("Empty", CreateList(nil))}
+	}
+	if m.activeTab >= len(m.tabs) {
+		m.activeTab = 0
+	}
+	cmd = tea.Batch(cmd, m.groupTabs())
+	if m.width > 0 {
+		m = m.handleWindowSize(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	}
+	m, detailCmd := m.resetDetail().syncDetail()
+	return m, tea.Batch(cmd, detailCmd)
+}
+
+// autoRefreshTick schedules the next background refresh, if auto-refresh is on.
+func (m Model) autoRefreshTick() tea.Cmd {
+	if m.refreshInterval <= 0 || m.fetchCmd == nil {
+		return nil
+	}
+	return tea.Tick(m.refreshInterval, func(time.Time) tea.Msg { return autoRefreshMsg{} })
+}
+
+// handleAutoRefresh starts a background refresh unless a fetch is already in
+// flight, and schedules the next one either way.
+func (m Model) handleAutoRefresh() (Model, tea.Cmd) {
+	next := m.autoRefreshTick()
+	if m.loading || m.stale() || m.refreshing {
+		return m, next
+	}
+	m.refreshing = true
+	return m, tea.Batch(m.spinner.Tick, m.fetchCmd, next)
+}
+
+// handleActionMsg handles the outcome of an action, its options, composed
+// text or the details of an item.
+func (m Model) handleActionMsg(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case actionDoneMsg:
+		return m.handleActionDone(msg)
+	case bulkDoneMsg:
+		return m.handleBulkDone(msg)
+	case optionsMsg:
+		return m.handleOptions(msg)
+	case composeMsg:
+		return m.handleCompose(msg)
+	case editedMsg:
+		return m.handleEdited(msg)
+	case detailMsg:
+		return m.handleDetail(msg)
+	}
+	return m, nil
+}
+
+// handleFetchMsg handles loading and refreshing the tabs.
+func (m Model) handleFetchMsg(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ErrMsg:
+		return m.handleFetchErr(msg.Err)
+	case TabsMsg:
+		return m.handleTabs([]Tab(msg))
+	case LoadedMsg:
+		m.info = msg.Info
+		return m.handleTabs(msg.Tabs)
+	case autoRefreshMsg:
+		return m.handleAutoRefresh()
+	case spinner.TickMsg:
+		if m.loading || m.stale() || m.refreshing {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// handleFetchErr keeps showing the current tabs when a refresh fails, and
+// quits when there are none.
+func (m Model) handleFetchErr(err error) (Model, tea.Cmd) {
+	if m.stale() || m.refreshing {
+		if m.stale() {
+			m.info = "showing cached results"
+		}
+		m.staleSince = time.Time{}
+		m.refreshing = false
+		m.statusMsg = "Refresh failed: " + err.Error()
+		return m, tea.Tick(5*time.Second, func(time.Time) tea.Msg { return clearStatusMsg{} })
+	}
+	m.err = err
+	return m, tea.Quit
+}
+
+// updateFocused passes msg on to the input being typed in, or else to the
+// list of the active tab.
+func (m Model) updateFocused(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	if m.pending != nil && m.pending.typing {
+		// Keep the text input's cursor blinking.
+		m.pending.input, cmd = m.pending.input.Update(msg)
+		return m, cmd
+	}
+	if m.pending != nil && m.pending.composing {
+		m.pending.area, cmd = m.pending.area.Update(msg)
+		return m, cmd
+	}
+	m.tabs[m.activeTab].list, cmd = m.tabs[m.activeTab].list.Update(msg)
+	m, detailCmd := m.syncDetail()
+	return m, tea.Batch(cmd, detailCmd)
+}
+
 func (m Model) handleWindowSize(msg tea.WindowSizeMsg) Model {
 	m.width, m.height = msg.Width, msg.Height
 
@@ -263,13 +593,17 @@ func (m Model) handleWindowSize(msg tea.WindowSizeMsg) Model {
 	innerW := max(20, m.outerW-winH)
 
 	tabsH := lipgloss.Height(m.tabsView())
-	helpH := lipgloss.Height(helpView(list.Unfiltered)) + 1 // +1 for newline
+	helpH := lipgloss.Height(helpView(list.Unfiltered, true)) + 1 // +1 for newline
+	if m.hasTabErrors() {
+		helpH++ // error line
+	}
 	m.outerH = max(5, m.height-docV-tabsH-helpH)
 
 	innerH := max(5, m.outerH-winV)
 
+	m, listW := m.sizeDetail(innerW, innerH)
 	for i := range m.tabs {
-		m.tabs[i].list.SetSize(innerW, innerH)
+		m.tabs[i].list.SetSize(listW, innerH)
 	}
 	return m
 }
@@ -281,11 +615,28 @@ func (m Model) handleKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
 
 	case "tab":
 		m.activeTab = (m.activeTab + 1) % len(m.tabs)
-		return m, nil, true
+		mm, cmd := m.syncDetail()
+		return mm, cmd, true
 
 	case "shift+tab":
 		m.activeTab = (m.activeTab - 1 + len(m.tabs)) % len(m.tabs)
-		return m, nil, true
+		mm, cmd := m.syncDetail()
+		return mm, cmd, true
+
+	case "v":
+		if mm, cmd, handled := m.toggleDetail(); handled {
+			return mm, cmd, true
+		}
+
+	case "s", "z", " ", "*":
+		if mm, cmd, handled := m.handleItemsKey(msg.String()); handled {
+			return mm, cmd, true
+		}
+
+	case "ctrl+d", "ctrl+u":
+		if mm, cmd, handled := m.scrollDetail(msg.String() == "ctrl+d"); handled {
+			return mm, cmd, true
+		}
 
 	case "enter":
 		return m.handleEnter()
@@ -293,16 +644,32 @@ func (m Model) handleKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
 	case "r":
 		return m.handleRefresh()
 	}
+	return m.startAction(msg.String())
+}
+
+// handleItemsKey handles the keys that sort, group or mark the items of the
+// active tab.
+func (m Model) handleItemsKey(key string) (Model, tea.Cmd, bool) {
+	switch key {
+	case "s":
+		return m.cycleSort()
+	case "z":
+		return m.toggleGroups()
+	case " ":
+		return m.toggleMark()
+	case "*":
+		return m.markAll()
+	}
 	return m, nil, false
 }
 
 func (m Model) handleRefresh() (Model, tea.Cmd, bool) {
-	if m.loading || m.fetchCmd == nil {
-		return m, nil, true
-	}
 	if m.tabs[m.activeTab].list.FilterState() == list.Filtering {
 		return m, nil, false
 	}
+	if m.loading || m.stale() || m.refreshing || m.fetchCmd == nil {
+		return m, nil, true
+	}
 	m.loading = true
 	return m, tea.Batch(m.spinner.Tick, m.fetchCmd), true
 }
@@ -312,6 +679,14 @@ func (m Model) handleEnter() (Model, tea.Cmd, bool) {
 		return m, nil, true
 	}
 
+	if mm, cmd, handled := m.toggleSection(); handled {
+		return mm, cmd, true
+	}
+
+	if marked := m.markedItems(); len(marked) > 0 {
+		return m.openMarked(marked)
+	}
+
 	sel := m.tabs[m.activeTab].list.SelectedItem()
 	it, ok := sel.(Item)
 	if !ok || it.url == "" {
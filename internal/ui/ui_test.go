@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
@@ -402,6 +403,54 @@ func TestModel_Update_TabsMsg(t *testing.T) {
 	}
 }
 
+func TestModel_Update_LoadedMsg_ShowsInfo(t *testing.T) {
+	m := NewLoadingModel(nil)
+
+	tabs := []Tab{NewTab("Created (3)", CreateList(nil))}
+	newModel, _ := m.Update(LoadedMsg{Tabs: tabs, Info: "API 4812/5000"})
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+	if m.loading {
+		t.Error("after LoadedMsg, loading should be false")
+	}
+
+	newModel, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 24})
+	m, ok = newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+
+	if view := m.View(); !strings.Contains(view, "API 4812/5000") {
+		t.Error("View() should contain the info from LoadedMsg")
+	}
+}
+
+func TestModel_WithInfo(t *testing.T) {
+	m := NewModel([]Tab{NewTab("Created (1)", CreateList(nil))}).WithInfo("offline, cached 2h ago")
+
+	newModel, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 24})
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+
+	if view := m.View(); !strings.Contains(view, "offline, cached 2h ago") {
+		t.Error("View() should contain the info set with WithInfo")
+	}
+}
+
+func TestModel_WithInfo_OmittedWhenNarrow(t *testing.T) {
+	m := NewModel(nil)
+	m.info = "API 4812/5000"
+	m.outerW = 10
+
+	if got := m.withInfo("help text"); got != "help text" {
+		t.Errorf("withInfo() = %q, want status unchanged", got)
+	}
+}
+
 func TestModel_Update_ErrMsg(t *testing.T) {
 	m := NewLoadingModel(nil)
 
@@ -446,6 +495,27 @@ func TestFetchCmd(t *testing.T) {
 	})
 }
 
+func TestFetchWithInfoCmd(t *testing.T) {
+	cmd := FetchWithInfoCmd(func() ([]Tab, string, error) {
+		return []Tab{NewTab("Tab 1", CreateList(nil))}, "API 1/2", nil
+	})
+
+	msg, ok := cmd().(LoadedMsg)
+	if !ok {
+		t.Fatalf("expected LoadedMsg, got %T", cmd())
+	}
+	if len(msg.Tabs) != 1 || msg.Info != "API 1/2" {
+		t.Errorf("LoadedMsg = %+v, want 1 tab and info %q", msg, "API 1/2")
+	}
+
+	cmd = FetchWithInfoCmd(func() ([]Tab, string, error) {
+		return nil, "", errors.New("network error")
+	})
+	if _, ok := cmd().(ErrMsg); !ok {
+		t.Errorf("expected ErrMsg, got %T", cmd())
+	}
+}
+
 func TestModel_WindowResize_DuringLoading(t *testing.T) {
 	m := NewLoadingModel(nil)
 
@@ -476,6 +546,267 @@ func TestModel_WindowResize_DuringLoading(t *testing.T) {
 	}
 }
 
+func TestNewStaleModel_ShowsCachedTabsWhileRefreshing(t *testing.T) {
+	fetch := FetchCmd(func() ([]Tab, error) { return nil, nil })
+	m := NewStaleModel([]Tab{NewTab("Cached (2)", CreateList(nil))}, time.Now().Add(-2*time.Hour), fetch)
+
+	if m.loading {
+		t.Error("stale model should not be loading")
+	}
+	if m.Init() == nil {
+		t.Error("Init() should start the background fetch")
+	}
+
+	newModel, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 24})
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+
+	view := m.View()
+	if strings.Contains(view, "Loading") {
+		t.Error("stale View() should show the cached tabs, not the spinner")
+	}
+	if !strings.Contains(view, "Cached (2)") {
+		t.Error("stale View() should contain the cached tab")
+	}
+	if !strings.Contains(view, "stale (2h ago), refreshing…") {
+		t.Error("stale View() should contain the stale indicator")
+	}
+}
+
+func TestNewStaleModel_FreshTabsReplaceCache(t *testing.T) {
+	m := NewStaleModel([]Tab{NewTab("Cached", CreateList(nil))}, time.Now(), nil)
+
+	newModel, _ := m.Update(LoadedMsg{Tabs: []Tab{NewTab("Fresh", CreateList(nil))}})
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+
+	if m.stale() {
+		t.Error("after LoadedMsg, model should no longer be stale")
+	}
+	if m.tabs[0].name != "Fresh" {
+		t.Errorf("tabs[0].name = %q, want %q", m.tabs[0].name, "Fresh")
+	}
+}
+
+func TestNewStaleModel_ErrMsgKeepsCachedTabs(t *testing.T) {
+	m := NewStaleModel([]Tab{NewTab("Cached", CreateList(nil))}, time.Now(), nil)
+
+	newModel, _ := m.Update(ErrMsg{Err: errors.New("network down")})
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+
+	if m.Err() != nil {
+		t.Errorf("Err() = %v, want nil so the cached tabs stay usable", m.Err())
+	}
+	if m.stale() {
+		t.Error("after a failed refresh, the refreshing indicator should stop")
+	}
+	if !strings.Contains(m.statusMsg, "network down") {
+		t.Errorf("statusMsg = %q, want the refresh error", m.statusMsg)
+	}
+	if m.tabs[0].name != "Cached" {
+		t.Errorf("tabs[0].name = %q, want cached tab kept", m.tabs[0].name)
+	}
+}
+
+func TestNewStaleModel_RefreshKeyIgnored(t *testing.T) {
+	fetch := FetchCmd(func() ([]Tab, error) { return nil, nil })
+	m := NewStaleModel([]Tab{NewTab("Cached", CreateList(nil))}, time.Now(), fetch)
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+
+	if m.loading {
+		t.Error("'r' while refreshing stale tabs should not show the spinner")
+	}
+	if cmd != nil {
+		t.Error("'r' while refreshing stale tabs should not start another fetch")
+	}
+}
+
+func TestNewStaleModel_RefreshKeyTypedInFilter(t *testing.T) {
+	fetch := FetchCmd(func() ([]Tab, error) { return nil, nil })
+	items := []list.Item{NewItem("repo", "title", "desc", "url")}
+	m := NewStaleModel([]Tab{NewTab("Cached", CreateList(items))}, time.Now(), fetch)
+	m, _ = update(t, m, tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	m, _ = update(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	m, _ = update(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+
+	if got := m.tabs[0].list.FilterValue(); got != "r" {
+		t.Errorf("FilterValue() = %q, want %q", got, "r")
+	}
+}
+
+// loadedModel returns a model that finished loading tabs with fetch.
+func loadedModel(t *testing.T, fetch tea.Cmd, tabs []Tab) Model {
+	t.Helper()
+	newModel, _ := NewLoadingModel(fetch).Update(TabsMsg(tabs))
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+	return m
+}
+
+func TestModel_WithAutoRefresh_Init(t *testing.T) {
+	fetch := FetchCmd(func() ([]Tab, error) { return nil, nil })
+	m := loadedModel(t, fetch, []Tab{NewTab("Created", CreateList(nil))})
+
+	if m.Init() != nil {
+		t.Error("Init() without auto-refresh should return nil")
+	}
+	if m.WithAutoRefresh(time.Minute).Init() == nil {
+		t.Error("Init() with auto-refresh should schedule a refresh")
+	}
+	if NewModel(nil).WithAutoRefresh(time.Minute).Init() != nil {
+		t.Error("Init() without a fetch command should not schedule a refresh")
+	}
+}
+
+func TestModel_Update_AutoRefresh(t *testing.T) {
+	fetch := FetchCmd(func() ([]Tab, error) { return nil, nil })
+	m := loadedModel(t, fetch, []Tab{NewTab("Created (1)", CreateList(nil))}).WithAutoRefresh(time.Minute)
+
+	newModel, cmd := m.Update(autoRefreshMsg{})
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+
+	if !m.refreshing {
+		t.Error("autoRefreshMsg should start a background refresh")
+	}
+	if m.loading {
+		t.Error("a background refresh should not show the loading spinner")
+	}
+	if cmd == nil {
+		t.Error("autoRefreshMsg should return the fetch command")
+	}
+
+	newModel, _ = m.Update(tea.WindowSizeMsg{Width: 100, Height: 24})
+	m, ok = newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+	view := m.View()
+	if !strings.Contains(view, "Created (1)") || !strings.Contains(view, "refreshing…") {
+		t.Error("View() should keep the tabs and show the refreshing indicator")
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m, ok = newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+	if m.loading {
+		t.Error("'r' during a background refresh should not start another fetch")
+	}
+
+	newModel, _ = m.Update(TabsMsg{NewTab("Created (2)", CreateList(nil))})
+	m, ok = newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+	if m.refreshing {
+		t.Error("refreshing should be false after TabsMsg")
+	}
+	if m.tabs[0].name != "Created (2)" {
+		t.Errorf("tabs[0].name = %q, want %q", m.tabs[0].name, "Created (2)")
+	}
+}
+
+func TestModel_Update_AutoRefreshSkippedWhileFetching(t *testing.T) {
+	fetch := FetchCmd(func() ([]Tab, error) { return nil, nil })
+	m := NewLoadingModel(fetch).WithAutoRefresh(time.Minute)
+
+	newModel, cmd := m.Update(autoRefreshMsg{})
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+
+	if m.refreshing {
+		t.Error("autoRefreshMsg during loading should not start a background refresh")
+	}
+	if cmd == nil {
+		t.Error("autoRefreshMsg should still schedule the next refresh")
+	}
+}
+
+func TestModel_Update_AutoRefreshErrorKeepsTabs(t *testing.T) {
+	fetch := FetchCmd(func() ([]Tab, error) { return nil, nil })
+	m := loadedModel(t, fetch, []Tab{NewTab("Created", CreateList(nil))}).WithAutoRefresh(time.Minute)
+	m.refreshing = true
+
+	newModel, _ := m.Update(ErrMsg{Err: errors.New("network down")})
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+
+	if m.Err() != nil {
+		t.Errorf("Err() = %v, want nil so the tabs stay usable", m.Err())
+	}
+	if m.refreshing {
+		t.Error("after a failed refresh, refreshing should be false")
+	}
+	if !strings.Contains(m.statusMsg, "network down") {
+		t.Errorf("statusMsg = %q, want the refresh error", m.statusMsg)
+	}
+}
+
+func TestModel_Update_TabsMsgMarksChanges(t *testing.T) {
+	first := []Tab{NewTab("Created", CreateList([]list.Item{
+		NewItem("owner/repo", "#1", "", "https://example.com/1"),
+	}))}
+	m := loadedModel(t, nil, first).WithAutoRefresh(time.Minute)
+
+	newModel, _ := m.Update(TabsMsg{NewTab("Created", CreateList([]list.Item{
+		NewItem("owner/repo", "#1", "", "https://example.com/1"),
+		NewItem("owner/repo", "#2", "", "https://example.com/2"),
+	}))})
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+
+	items := m.tabs[0].list.Items()
+	if it, ok := items[0].(Item); !ok || it.change != unchanged {
+		t.Errorf("items[0].change = %v, want unchanged", it.change)
+	}
+	if it, ok := items[1].(Item); !ok || it.change != changeNew {
+		t.Errorf("items[1].change = %v, want changeNew", it.change)
+	}
+}
+
+func TestNewStaleModel_FreshTabsNotMarkedWithoutWatch(t *testing.T) {
+	cached := []Tab{NewTab("Created", CreateList([]list.Item{
+		NewItem("owner/repo", "#1", "", "https://example.com/1"),
+	}))}
+	m := NewStaleModel(cached, time.Now(), nil)
+
+	m, _ = update(t, m, TabsMsg{NewTab("Created", CreateList([]list.Item{
+		NewItem("owner/repo", "#1", "", "https://example.com/1").WithSuffix(" ✓"),
+		NewItem("owner/repo", "#2", "", "https://example.com/2"),
+	}))})
+
+	for i, li := range m.tabs[0].list.Items() {
+		if it, ok := li.(Item); !ok || it.change != unchanged {
+			t.Errorf("items[%d].change = %v, want unchanged without --watch", i, it.change)
+		}
+	}
+}
+
 func TestModel_Update_RefreshKey(t *testing.T) {
 	fetch := FetchCmd(func() ([]Tab, error) {
 		return []Tab{NewTab("Refreshed", CreateList(nil))}, nil
@@ -632,7 +963,7 @@ func TestModel_Update_RefreshKey_FullCycle(t *testing.T) {
 }
 
 func TestHelpView_Unfiltered_ContainsRefresh(t *testing.T) {
-	view := helpView(list.Unfiltered)
+	view := helpView(list.Unfiltered, true)
 	if !strings.Contains(view, "r") {
 		t.Error("helpView(Unfiltered) should contain 'r' key")
 	}
@@ -641,22 +972,34 @@ func TestHelpView_Unfiltered_ContainsRefresh(t *testing.T) {
 	}
 }
 
+func TestHelpView_HidesRefreshWhenUnavailable(t *testing.T) {
+	if view := helpView(list.Unfiltered, false); strings.Contains(view, "refresh") {
+		t.Error("helpView() should not list refresh when the results cannot be refreshed")
+	}
+
+	m := NewModel([]Tab{NewTab("Cached", CreateList(nil))})
+	m, _ = update(t, m, tea.WindowSizeMsg{Width: 120, Height: 24})
+	if strings.Contains(m.View(), "r refresh") {
+		t.Error("View() should not list refresh without a fetch command, e.g. offline")
+	}
+}
+
 func TestHelpView_FilterApplied_ContainsClear(t *testing.T) {
-	view := helpView(list.FilterApplied)
+	view := helpView(list.FilterApplied, true)
 	if !strings.Contains(view, "clear") {
 		t.Error("helpView(FilterApplied) should contain 'clear'")
 	}
 }
 
 func TestHelpView_Filtering_ContainsEsc(t *testing.T) {
-	view := helpView(list.Filtering)
+	view := helpView(list.Filtering, true)
 	if !strings.Contains(view, "esc") {
 		t.Error("helpView(Filtering) should contain 'esc'")
 	}
 }
 
 func TestHelpView_Filtering_HidesRefresh(t *testing.T) {
-	view := helpView(list.Filtering)
+	view := helpView(list.Filtering, true)
 	if strings.Contains(view, "refresh") {
 		t.Error("helpView(Filtering) should not contain 'refresh'")
 	}
@@ -678,6 +1021,33 @@ func TestModel_View(t *testing.T) {
 	}
 }
 
+func TestModel_View_ShowsActiveTabError(t *testing.T) {
+	m := NewModel([]Tab{
+		NewTab("Created (0)", CreateList(nil)),
+		NewTab("Needs Triage (error: invalid query)", CreateList(nil)).WithError(errors.New("invalid query: unknown qualifier")),
+	})
+
+	newModel, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 24})
+	m, ok := newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+
+	if strings.Contains(m.View(), "unknown qualifier") {
+		t.Error("View() should not show another tab's error")
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m, ok = newModel.(Model)
+	if !ok {
+		t.Fatal("expected Model type")
+	}
+
+	if !strings.Contains(m.View(), "error: invalid query: unknown qualifier") {
+		t.Error("View() should show the active tab's full error")
+	}
+}
+
 func TestModel_View_ShowsFilterHelpWhenFiltering(t *testing.T) {
 	items := []list.Item{NewItem("owner/repo", "PR title", "desc", "https://example.com")}
 	m := NewModel([]Tab{NewTab("Test Tab", CreateList(items))})
@@ -699,3 +1069,14 @@ func TestModel_View_ShowsFilterHelpWhenFiltering(t *testing.T) {
 		t.Error("View() should contain 'esc' when filtering is active")
 	}
 }
+
+func TestTab_WithTitle(t *testing.T) {
+	items := []list.Item{NewItem("owner/repo", "#1", "", "https://example.com/1")}
+	tab := NewTab("", CreateList(items)).WithTitle(5, func(shown, total int) string {
+		return TabTitle("Created", shown, total)
+	})
+
+	if tab.Name() != "Created (1 of 5)" {
+		t.Errorf("Name() = %q, want %q", tab.Name(), "Created (1 of 5)")
+	}
+}